@@ -12,6 +12,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/hashicorp/logutils"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint/formatter"
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/tflint"
@@ -32,6 +33,18 @@ type CLI struct {
 	originalWorkingDir   string
 	sources              map[string][]byte
 
+	// fs is the filesystem to load modules from.
+	// This is an in-memory overlay when printing diffs of fixes.
+	fs afero.Fs
+
+	// fixedIssues and fixedSources are the changes made by --fix.
+	// fixedSources holds the sources before fixing.
+	fixedIssues  tflint.Issues
+	fixedSources map[string][]byte
+
+	// profiler records the time spent in inspection. Nil if --profile is not set.
+	profiler *tflint.Profiler
+
 	// fields for each module
 	config    *tflint.Config
 	loader    *terraform.Loader
@@ -47,6 +60,9 @@ func NewCLI(outStream io.Writer, errStream io.Writer) (*CLI, error) {
 		errStream:          errStream,
 		originalWorkingDir: wd,
 		sources:            map[string][]byte{},
+		fs:                 afero.NewOsFs(),
+		fixedIssues:        tflint.Issues{},
+		fixedSources:       map[string][]byte{},
 	}, err
 }
 
@@ -58,6 +74,10 @@ func (cli *CLI) Run(args []string) int {
 	parser.UnknownOptionHandler = unknownOptionHandler
 	// Parse commandline flag
 	args, err := parser.ParseArgs(args)
+	if option := parser.FindOptionByLongName("config"); option != nil {
+		opts.configSet = option.IsSet() && !option.IsSetDefault()
+	}
+	opts.rootDir = cli.originalWorkingDir
 	// Set up output formatter
 	cli.formatter = &formatter.Formatter{
 		Stdout: cli.outStream,
@@ -105,6 +125,21 @@ func (cli *CLI) Run(args []string) int {
 			fmt.Fprintln(cli.errStream, `WARNING: Arguments are not used in language server mode and will error in a future version.`)
 		}
 		return cli.startLanguageServer(opts)
+	case opts.PrintConfig != "":
+		if len(args) > 1 {
+			fmt.Fprintln(cli.errStream, `WARNING: Arguments are not used in print config mode. Use --chdir instead.`)
+		}
+		return cli.printConfig(opts)
+	case opts.ValidateConfig:
+		if len(args) > 1 {
+			fmt.Fprintln(cli.errStream, `WARNING: Arguments are not used in validate config mode. Use --chdir instead.`)
+		}
+		return cli.validateConfig(opts)
+	case opts.ConfigSchema:
+		if len(args) > 1 {
+			fmt.Fprintln(cli.errStream, `WARNING: Arguments are not used in config schema mode. Use --chdir instead.`)
+		}
+		return cli.printConfigSchema(opts)
 	case opts.ActAsBundledPlugin:
 		return cli.actAsBundledPlugin()
 	default:
@@ -151,8 +186,22 @@ func findWorkingDirs(opts Options) ([]string, error) {
 	workingDirs := []string{}
 
 	if opts.Recursive {
+		// Directories excluded by the .tflintignore or the `exclude` in the root config are skipped
+		cfg, err := loadConfig(opts)
+		if err != nil {
+			return []string{}, fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		wd, err := os.Getwd()
+		if err != nil {
+			return []string{}, err
+		}
+		excludes, err := loadExcludes(wd, cfg)
+		if err != nil {
+			return []string{}, err
+		}
+
 		// NOTE: The target directory is always the current directory in recursive mode
-		err := filepath.WalkDir(".", func(path string, d os.DirEntry, err error) error {
+		err = filepath.WalkDir(".", func(path string, d os.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
@@ -163,6 +212,10 @@ func findWorkingDirs(opts Options) ([]string, error) {
 			if path != "." && strings.HasPrefix(d.Name(), ".") {
 				return filepath.SkipDir
 			}
+			if excludes.Match(path, true) {
+				log.Printf("[INFO] %s is excluded", path)
+				return filepath.SkipDir
+			}
 
 			workingDirs = append(workingDirs, path)
 			return nil
@@ -181,6 +234,98 @@ func findWorkingDirs(opts Options) ([]string, error) {
 	return workingDirs, nil
 }
 
+// loadExcludes returns patterns to exclude files and directories from inspection.
+// The .tflintignore in the original working directory applies to all working directories.
+// The .tflintignore in the current directory is relative to the current directory.
+// The `exclude` patterns are relative to the directory of the config file declaring them.
+func loadExcludes(originalWd string, config *tflint.Config) (*terraform.Excludes, error) {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	excludes := terraform.NewExcludes()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	baseDir, err := filepath.Rel(originalWd, wd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := excludes.LoadIgnoreFile(fs, originalWd, "."); err != nil {
+		return nil, fmt.Errorf("Failed to load %s; %w", terraform.IgnoreFilename, err)
+	}
+	if baseDir != "." {
+		if err := excludes.LoadIgnoreFile(fs, ".", baseDir); err != nil {
+			return nil, fmt.Errorf("Failed to load %s; %w", terraform.IgnoreFilename, err)
+		}
+	}
+	baseDirs := config.ExcludeBaseDirs()
+	for i, pattern := range config.Exclude {
+		patternBaseDir := baseDirs[i]
+		if patternBaseDir == "" {
+			patternBaseDir = wd
+		}
+		if err := excludes.AddAt(originalWd, patternBaseDir, pattern); err != nil {
+			return nil, fmt.Errorf("Failed to parse `exclude`; %w", err)
+		}
+	}
+
+	return excludes, nil
+}
+
+// loadConfig loads the config files passed by --config in the current directory.
+// In recursive mode, if --config is not passed, the config files are discovered
+// from the current directory up to the repository root and merged, with the nearest taking precedence.
+// Outside a repository, the discovery stops at the directory where TFLint is run.
+// The profile selected by --config-profile or TFLINT_PROFILE is layered over the loaded config.
+func loadConfig(opts Options) (*tflint.Config, error) {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+
+	files := opts.Config
+	if opts.Recursive && !opts.configSet {
+		root := opts.rootDir
+		if root == "" {
+			root = "."
+		}
+		found, err := tflint.FindConfigFiles(fs, ".", root)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 0 {
+			files = found
+		}
+	}
+
+	config, err := tflint.LoadConfig(fs, files...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ConfigProfile != "" {
+		if err := config.ApplyProfile(opts.ConfigProfile); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// setupConfig loads the config files and merges the config from CLI options
+func setupConfig(opts Options) (*tflint.Config, error) {
+	config, err := loadConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load TFLint config; %w", err)
+	}
+	// tflint-plugin-sdk v0.13+ doesn't need to disable rules config when enabling the only option.
+	// This is for the backward compatibility.
+	if len(opts.Only) > 0 {
+		for _, rule := range config.Rules {
+			rule.Enabled = false
+		}
+	}
+	config.Merge(opts.toConfig())
+
+	return config, nil
+}
+
 func (cli *CLI) withinChangedDir(dir string, proc func() error) (err error) {
 	if dir != "." {
 		chErr := os.Chdir(dir)
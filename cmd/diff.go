@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines around changes in unified diffs
+const diffContextLines = 3
+
+type diffOpKind int
+
+const (
+	diffEqual diffOpKind = iota
+	diffDelete
+	diffInsert
+)
+
+type diffOp struct {
+	kind diffOpKind
+	line string
+	// aIdx and bIdx are the line indexes before and after the operation
+	aIdx, bIdx int
+}
+
+// unifiedDiff returns the unified diff between the passed sources.
+// It returns an empty string if the sources are the same.
+func unifiedDiff(filename string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(before)), splitLines(string(after)))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", filename, filename)
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == diffEqual {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are close enough
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != diffEqual {
+				end = i + 1
+			} else if i-end >= diffContextLines*2 {
+				break
+			}
+		}
+
+		hunkStart := start - diffContextLines
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := end + diffContextLines
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+		writeHunk(&out, ops[hunkStart:hunkEnd])
+
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, ops []diffOp) {
+	aStart, bStart := ops[0].aIdx, ops[0].bIdx
+	aLen, bLen := 0, 0
+	for _, op := range ops {
+		if op.kind != diffInsert {
+			aLen++
+		}
+		if op.kind != diffDelete {
+			bLen++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+
+	for _, op := range ops {
+		prefix := " "
+		switch op.kind {
+		case diffDelete:
+			prefix = "-"
+		case diffInsert:
+			prefix = "+"
+		}
+		out.WriteString(prefix + op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		// Empty ranges refer to the line before the change
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// splitLines splits the source into lines, keeping newlines
+func splitLines(src string) []string {
+	lines := strings.SplitAfter(src, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script between the lines using Myers' algorithm.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+2)
+	trace := [][]int{}
+
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		trace = append(trace, append([]int{}, v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Backtrack from the end to build the edit script
+	ops := []diffOp{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: diffEqual, line: a[x], aIdx: x, bIdx: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, diffOp{kind: diffInsert, line: b[y], aIdx: x, bIdx: y})
+			} else {
+				x--
+				ops = append(ops, diffOp{kind: diffDelete, line: a[x], aIdx: x, bIdx: y})
+			}
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
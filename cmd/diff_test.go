@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "no changes",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "delete a line",
+			before: "a\nb\nc\nd\ne\nf\ng\n",
+			after:  "a\nb\nc\ne\nf\ng\n",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,7 +1,6 @@
+ a
+ b
+ c
+-d
+ e
+ f
+ g
+`,
+		},
+		{
+			name:   "replace a line",
+			before: "a\nb\nc\n",
+			after:  "a\nx\nc\n",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,3 @@
+ a
+-b
++x
+ c
+`,
+		},
+		{
+			name:   "insert into an empty file",
+			before: "",
+			after:  "a\n",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -0,0 +1 @@
++a
+`,
+		},
+		{
+			name:   "multiple hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			after:  "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,4 @@
+-1
++x
+ 2
+ 3
+ 4
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++y
+`,
+		},
+		{
+			name:   "no newline at end of file",
+			before: "a\nb",
+			after:  "a\nc",
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := unifiedDiff("main.tf", []byte(test.before), []byte(test.after))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
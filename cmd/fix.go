@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// maxFixPasses limits re-inspections in case fixes keep producing new fixable issues
+const maxFixPasses = 10
+
+// inspectModuleWithFixes inspects the module and applies fixes to files, and repeats it
+// until no fixes are applied. It returns issues found in the last inspection.
+// If --diff is set, fixes are written to memory instead of files.
+func (cli *CLI) inspectModuleWithFixes(opts Options, dir string, filterFiles []string) (tflint.Issues, error) {
+	if opts.Diff {
+		cli.fs = afero.NewCopyOnWriteFs(afero.NewOsFs(), afero.NewMemMapFs())
+		defer func() { cli.fs = afero.NewOsFs() }()
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return tflint.Issues{}, err
+	}
+
+	for pass := 1; ; pass++ {
+		issues, err := cli.inspectModule(opts, dir, filterFiles)
+		if err != nil {
+			return issues, err
+		}
+
+		sources := cli.loader.Sources()
+		changes, fixed := tflint.ApplyFixes(issues, sources)
+		if len(changes) == 0 {
+			return issues, nil
+		}
+		if pass > maxFixPasses {
+			log.Printf("[WARN] Stop fixing after %d passes. There are still fixable issues", maxFixPasses)
+			return issues, nil
+		}
+
+		for filename, src := range changes {
+			if err := cli.writeFixedFile(wd, filename, src); err != nil {
+				return tflint.Issues{}, fmt.Errorf("Failed to fix %s; %w", filename, err)
+			}
+			if _, exists := cli.fixedSources[filename]; !exists {
+				cli.fixedSources[filename] = sources[filename]
+			}
+		}
+		for _, issue := range fixed {
+			log.Printf("[INFO] %s (%s) is fixed", issue.Range.String(), issue.Rule.Name())
+		}
+		cli.fixedIssues = append(cli.fixedIssues, fixed...)
+	}
+}
+
+// writeFixedFile writes the fixed source. Source names are relative to
+// the original working directory, so resolve it from the current directory.
+func (cli *CLI) writeFixedFile(wd string, filename string, src []byte) error {
+	path := filename
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cli.originalWorkingDir, path)
+	}
+	path, err := filepath.Rel(wd, path)
+	if err != nil {
+		return err
+	}
+
+	info, err := cli.fs.Stat(path)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(cli.fs, path, src, info.Mode())
+}
+
+// printFixedIssues prints the issues fixed by --fix to stderr,
+// so as not to break the output of formats like JSON.
+func (cli *CLI) printFixedIssues() {
+	if len(cli.fixedIssues) == 0 {
+		return
+	}
+
+	fmt.Fprintf(cli.errStream, "%d issue(s) fixed:\n", len(cli.fixedIssues))
+	for _, issue := range cli.fixedIssues {
+		fmt.Fprintf(cli.errStream, "  %s: %s (%s)\n", issue.Range.String(), issue.Fix.Message, issue.Rule.Name())
+	}
+}
+
+// printFixDiffs prints the changes made by --fix --diff as unified diffs.
+// It returns a non-zero status if there are any changes, like fixable issues found.
+func (cli *CLI) printFixDiffs() int {
+	filenames := make([]string, 0, len(cli.fixedSources))
+	for filename := range cli.fixedSources {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	changed := false
+	for _, filename := range filenames {
+		diff := unifiedDiff(filepath.ToSlash(filename), cli.fixedSources[filename], cli.sources[filename])
+		if diff != "" {
+			fmt.Fprint(cli.outStream, diff)
+			changed = true
+		}
+	}
+
+	if changed {
+		return ExitCodeIssuesFound
+	}
+	return ExitCodeOK
+}
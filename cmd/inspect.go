@@ -26,7 +26,23 @@ func (cli *CLI) inspect(opts Options, args []string) int {
 		return ExitCodeError
 	}
 
+	if opts.Diff && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Cannot use --diff without --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	if (opts.MaxErrors != nil && *opts.MaxErrors < 0) || (opts.MaxWarnings != nil && *opts.MaxWarnings < 0) {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--max-errors and --max-warnings must be zero or more"), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	if opts.Profile != "" || opts.ProfileTrace != "" {
+		cli.profiler = tflint.NewProfiler()
+	}
+
 	issues := tflint.Issues{}
+	// Rule budgets are counted per working directory because each directory has its own config
+	ruleBudgetScopes := []ruleBudgetScope{}
 
 	for _, wd := range workingDirs {
 		err := cli.withinChangedDir(wd, func() error {
@@ -62,11 +78,17 @@ func (cli *CLI) inspect(opts Options, args []string) int {
 			for i, file := range filterFiles {
 				filterFiles[i] = filepath.Join(wd, file)
 			}
-			moduleIssues, err := cli.inspectModule(opts, targetDir, filterFiles)
+			var moduleIssues tflint.Issues
+			if opts.Fix {
+				moduleIssues, err = cli.inspectModuleWithFixes(opts, targetDir, filterFiles)
+			} else {
+				moduleIssues, err = cli.inspectModule(opts, targetDir, filterFiles)
+			}
 			if err != nil {
 				return err
 			}
 			issues = append(issues, moduleIssues...)
+			ruleBudgetScopes = append(ruleBudgetScopes, ruleBudgetScope{rules: cli.config.Rules, issues: moduleIssues})
 			return nil
 		})
 		if err != nil {
@@ -79,6 +101,13 @@ func (cli *CLI) inspect(opts Options, args []string) int {
 		}
 	}
 
+	if opts.Diff {
+		return cli.printFixDiffs()
+	}
+	if opts.Fix {
+		cli.printFixedIssues()
+	}
+
 	var force bool
 	if opts.Recursive {
 		// Respect "--format" and "--force" flags in recursive mode
@@ -91,15 +120,103 @@ func (cli *CLI) inspect(opts Options, args []string) int {
 		force = cli.config.Force
 	}
 
+	if opts.WriteBaseline != "" || opts.Baseline != "" {
+		baselineIssues, err := cli.applyBaseline(opts, issues)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+			return ExitCodeError
+		}
+		issues = append(issues, baselineIssues...)
+		ruleBudgetScopes = append(ruleBudgetScopes, ruleBudgetScope{issues: baselineIssues})
+	}
+
 	cli.formatter.Print(issues, nil, cli.sources)
 
-	if len(issues) > 0 && !force && exceedsMinimumFailure(issues, opts.MinimumFailureSeverity) {
+	if err := cli.printProfile(opts); err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+		return ExitCodeError
+	}
+
+	if force {
+		return ExitCodeOK
+	}
+
+	// Suppressed issues are printed in some formats, but they don't affect the exit status.
+	// Issues covered by budgets fail only when the budget is exceeded.
+	budgets := []*tflint.Budget{}
+	failures := tflint.Issues{}
+	for _, scope := range ruleBudgetScopes {
+		ruleBudgets, rest := tflint.RuleBudgets(filterMinimumFailure(scope.issues.Unsuppressed(), opts.MinimumFailureSeverity), scope.rules)
+		budgets = append(budgets, ruleBudgets...)
+		failures = append(failures, rest...)
+	}
+	severityBudgets, failures := tflint.SeverityBudgets(failures, opts.MaxErrors, opts.MaxWarnings)
+	budgets = append(budgets, severityBudgets...)
+
+	exceeded := false
+	for _, budget := range budgets {
+		if budget.Exceeded() {
+			fmt.Fprintf(cli.errStream, "Budget exceeded for %s\n", budget)
+			exceeded = true
+		}
+	}
+	if exceeded || len(failures) > 0 {
 		return ExitCodeIssuesFound
 	}
 
 	return ExitCodeOK
 }
 
+// ruleBudgetScope is issues found in a working directory and the rule config that applies to them
+type ruleBudgetScope struct {
+	rules  map[string]*tflint.RuleConfig
+	issues tflint.Issues
+}
+
+// applyBaseline hides issues recorded in the baseline file, and returns issues for unused entries.
+// If --write-baseline is set, the current issues are recorded before applying.
+func (cli *CLI) applyBaseline(opts Options, issues tflint.Issues) (tflint.Issues, error) {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+
+	path := opts.Baseline
+	if opts.WriteBaseline != "" {
+		path = opts.WriteBaseline
+		if err := tflint.NewBaseline(issues).Write(fs, path); err != nil {
+			return tflint.Issues{}, fmt.Errorf("Failed to write the baseline file; %w", err)
+		}
+	}
+
+	baseline, err := tflint.LoadBaseline(fs, path)
+	if err != nil {
+		return tflint.Issues{}, fmt.Errorf("Failed to load the baseline file; %w", err)
+	}
+
+	// In recursive mode, cli.config is the config of the last inspected directory,
+	// so load the config in the current directory as the baseline is shared by all directories.
+	config := cli.config
+	if opts.Recursive {
+		config, err = setupConfig(opts)
+		if err != nil {
+			return tflint.Issues{}, err
+		}
+		// Launch plugins to expand rule patterns, such as `--disable-rule 'tflint_*'`
+		rulesetPlugin, err := launchPlugins(config)
+		if rulesetPlugin != nil {
+			defer rulesetPlugin.Clean()
+		}
+		if err != nil {
+			return tflint.Issues{}, err
+		}
+	}
+	baselineIssues := baseline.Apply(issues, config, cli.sources)
+
+	// Add the baseline file to sources to show unused entries
+	if src, err := fs.ReadFile(path); err == nil {
+		cli.sources[path] = src
+	}
+	return baselineIssues, nil
+}
+
 func processArgs(args []string) (string, []string, error) {
 	if len(args) == 0 {
 		return ".", []string{}, nil
@@ -148,31 +265,29 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 	var err error
 
 	// Setup config
-	cli.config, err = tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cli.config, err = setupConfig(opts)
 	if err != nil {
-		return tflint.Issues{}, fmt.Errorf("Failed to load TFLint config; %w", err)
-	}
-	// tflint-plugin-sdk v0.13+ doesn't need to disable rules config when enabling the only option.
-	// This is for the backward compatibility.
-	if len(opts.Only) > 0 {
-		for _, rule := range cli.config.Rules {
-			rule.Enabled = false
-		}
+		return tflint.Issues{}, err
 	}
-	cli.config.Merge(opts.toConfig())
 
 	// Setup loader
-	cli.loader, err = terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir)
+	cli.loader, err = terraform.NewLoader(afero.Afero{Fs: cli.fs}, cli.originalWorkingDir)
 	if err != nil {
 		return tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err)
 	}
+	excludes, err := loadExcludes(cli.originalWorkingDir, cli.config)
+	if err != nil {
+		return tflint.Issues{}, err
+	}
+	cli.loader.SetExcludes(excludes)
+
 	if opts.Recursive && !cli.loader.IsConfigDir(dir) {
 		// Ignore non-module directories in recursive mode
 		return tflint.Issues{}, nil
 	}
 
 	// Setup runners
-	runners, err := cli.setupRunners(opts, dir)
+	runners, err := cli.setupRunners(opts, dir, excludes)
 	if err != nil {
 		return tflint.Issues{}, err
 	}
@@ -187,6 +302,10 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 		return tflint.Issues{}, err
 	}
 
+	// Core rules are checked after launching plugins, as rule patterns are expanded then
+	rootRunner.CheckProviderLocks()
+	rootRunner.CheckAnnotations()
+
 	// Run inspection
 	for name, ruleset := range rulesetPlugin.RuleSets {
 		sdkVersion, err := ruleset.SDKVersion()
@@ -200,25 +319,44 @@ func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (t
 		}
 
 		for _, runner := range runners {
+			end := cli.profiler.Start(tflint.ProfileCategoryCheck, name, runner.ModuleInstance)
 			err = ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, cli.loader.Files(), sdkVersion))
+			end()
 			if err != nil {
 				return tflint.Issues{}, fmt.Errorf("Failed to check ruleset; %w", err)
 			}
 		}
 	}
+	rootRunner.CheckUnusedAnnotations()
 
 	for _, runner := range runners {
-		issues = append(issues, runner.LookupIssues(filterFiles...)...)
+		runnerIssues := tflint.Issues{}
+		runnerIssues = append(runnerIssues, runner.LookupIssues(filterFiles...)...)
+		runnerIssues = append(runnerIssues, runner.LookupSuppressedIssues(filterFiles...)...)
+		for _, issue := range runnerIssues {
+			issue.Fingerprint = tflint.Fingerprint(issue, cli.loader.Files())
+			issue.Address = tflint.Address(issue, runner.ModuleInstance, cli.loader.Files())
+			if !runner.ModuleInstance.IsRoot() {
+				issue.Instances = []*tflint.IssueInstance{{Module: runner.ModuleInstance, Callers: issue.Callers}}
+			}
+		}
+		issues = append(issues, runnerIssues...)
 	}
+	// Collapse the same issues emitted by multiple module instances
+	issues = issues.Dedupe()
 	// Set module sources to CLI
 	for path, source := range cli.loader.Sources() {
 		cli.sources[path] = source
 	}
+	// Add config sources to show issues on ignore blocks
+	for path, source := range cli.config.Sources() {
+		cli.sources[path] = source
+	}
 
 	return issues, nil
 }
 
-func (cli *CLI) setupRunners(opts Options, dir string) ([]*tflint.Runner, error) {
+func (cli *CLI) setupRunners(opts Options, dir string, excludes *terraform.Excludes) ([]*tflint.Runner, error) {
 	configs, diags := cli.loader.LoadConfig(dir, cli.config.Module)
 	if diags.HasErrors() {
 		return []*tflint.Runner{}, fmt.Errorf("Failed to load configurations; %w", diags)
@@ -230,9 +368,6 @@ func (cli *CLI) setupRunners(opts Options, dir string) ([]*tflint.Runner, error)
 	}
 	annotations := map[string]tflint.Annotations{}
 	for path, file := range files {
-		if !strings.HasSuffix(path, ".tf") {
-			continue
-		}
 		ants, lexDiags := tflint.NewAnnotations(path, file)
 		diags = diags.Extend(lexDiags)
 		annotations[path] = ants
@@ -251,10 +386,18 @@ func (cli *CLI) setupRunners(opts Options, dir string) ([]*tflint.Runner, error)
 	}
 	variables = append(variables, cliVars)
 
+	locks, diags := cli.loader.LoadProviderLocks(dir)
+	if diags.HasErrors() {
+		return []*tflint.Runner{}, fmt.Errorf("Failed to load the dependency lock file; %w", diags)
+	}
+
 	runner, err := tflint.NewRunner(cli.originalWorkingDir, cli.config, annotations, configs, variables...)
 	if err != nil {
 		return []*tflint.Runner{}, fmt.Errorf("Failed to initialize a runner; %w", err)
 	}
+	runner.ProviderLocks = locks
+	runner.Excludes = excludes
+	runner.Profiler = cli.profiler
 
 	runners, err := tflint.NewModuleRunners(runner)
 	if err != nil {
@@ -272,9 +415,8 @@ func launchPlugins(config *tflint.Config) (*plugin.Plugin, error) {
 	}
 
 	rulesets := []tflint.RuleSet{}
-	pluginConf := config.ToPluginConfig()
 
-	// Check version constraints and apply a config to plugins
+	// Check version constraints
 	for name, ruleset := range rulesetPlugin.RuleSets {
 		constraints, err := ruleset.VersionConstraints()
 		if err != nil {
@@ -289,6 +431,17 @@ func launchPlugins(config *tflint.Config) (*plugin.Plugin, error) {
 			return rulesetPlugin, fmt.Errorf("Failed to satisfy version constraints; tflint-ruleset-%s requires %s, but TFLint version is %s", name, constraints, tflint.Version)
 		}
 
+		rulesets = append(rulesets, ruleset)
+	}
+
+	// Expand rule patterns with the rule names of plugins before passing the config to them
+	if err := config.ExpandRulePatterns(rulesets...); err != nil {
+		return rulesetPlugin, fmt.Errorf("Failed to check rule config; %w", err)
+	}
+	pluginConf := config.ToPluginConfig()
+
+	// Apply a config to plugins
+	for name, ruleset := range rulesetPlugin.RuleSets {
 		if err := ruleset.ApplyGlobalConfig(pluginConf); err != nil {
 			return rulesetPlugin, fmt.Errorf("Failed to apply global config to `%s` plugin; %w", name, err)
 		}
@@ -308,8 +461,6 @@ func launchPlugins(config *tflint.Config) (*plugin.Plugin, error) {
 		if err != nil {
 			return rulesetPlugin, fmt.Errorf("Failed to apply config to `%s` plugin; %w", name, err)
 		}
-
-		rulesets = append(rulesets, ruleset)
 	}
 
 	// Validate config for plugins
@@ -320,29 +471,30 @@ func launchPlugins(config *tflint.Config) (*plugin.Plugin, error) {
 	return rulesetPlugin, nil
 }
 
-// Checks if the given issues contain severities above or equal to the given minimum failure opt. Defaults to true if an error occurs
-func exceedsMinimumFailure(issues tflint.Issues, minimumFailureOpt string) bool {
-	if minimumFailureOpt != "" {
-		minSeverity, err := tflint.NewSeverity(minimumFailureOpt)
-		if err != nil {
-			return true
-		}
+// Returns issues with severities above or equal to the given minimum failure opt. Returns all issues if an error occurs
+func filterMinimumFailure(issues tflint.Issues, minimumFailureOpt string) tflint.Issues {
+	if minimumFailureOpt == "" {
+		return issues
+	}
+
+	minSeverity, err := tflint.NewSeverity(minimumFailureOpt)
+	if err != nil {
+		return issues
+	}
+	minSeverityInt32, err := tflint.SeverityToInt32(minSeverity)
+	if err != nil {
+		return issues
+	}
 
-		minSeverityInt32, err := tflint.SeverityToInt32(minSeverity)
+	ret := tflint.Issues{}
+	for _, i := range issues {
+		ruleSeverityInt32, err := tflint.SeverityToInt32(i.Rule.Severity())
 		if err != nil {
-			return true
+			return issues
 		}
-
-		for _, i := range issues {
-			ruleSeverityInt32, err := tflint.SeverityToInt32(i.Rule.Severity())
-			if err != nil {
-				return true
-			}
-			if ruleSeverityInt32 >= minSeverityInt32 {
-				return true
-			}
+		if ruleSeverityInt32 >= minSeverityInt32 {
+			ret = append(ret, i)
 		}
-		return false
 	}
-	return true
+	return ret
 }
@@ -20,12 +20,12 @@ func (cli *CLI) startLanguageServer(opts Options) int {
 		return ExitCodeError
 	}
 
-	configPath := opts.Config
+	configPaths := opts.Config
 	cliConfig := opts.toConfig()
 
 	log.Println("Starting language server...")
 
-	handler, plugin, err := langserver.NewHandler(configPath, cliConfig)
+	handler, plugin, err := langserver.NewHandler(configPaths, opts.ConfigProfile, cliConfig)
 	if err != nil {
 		log.Printf("Failed to start language server: %s", err)
 		return ExitCodeError
@@ -9,28 +9,48 @@ import (
 
 // Options is an option specified by arguments.
 type Options struct {
-	Version                bool     `short:"v" long:"version" description:"Print TFLint version"`
-	Init                   bool     `long:"init" description:"Install plugins"`
-	Langserver             bool     `long:"langserver" description:"Start language server"`
-	Format                 string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif"`
-	Config                 string   `short:"c" long:"config" description:"Config file name" value-name:"FILE" default:".tflint.hcl"`
-	IgnoreModules          []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
-	EnableRules            []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
-	DisableRules           []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
-	Only                   []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
-	EnablePlugins          []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
-	Varfiles               []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
-	Variables              []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
-	Module                 *bool    `long:"module" description:"Enable module inspection"`
-	NoModule               *bool    `long:"no-module" description:"Disable module inspection"`
-	Chdir                  string   `long:"chdir" description:"Switch to a different working directory before executing the command" value-name:"DIR"`
-	Recursive              bool     `long:"recursive" description:"Run command in each directory recursively"`
-	Filter                 []string `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
-	Force                  *bool    `long:"force" description:"Return zero exit status even if issues found"`
-	MinimumFailureSeverity string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
-	Color                  bool     `long:"color" description:"Enable colorized output"`
-	NoColor                bool     `long:"no-color" description:"Disable colorized output"`
-	ActAsBundledPlugin     bool     `long:"act-as-bundled-plugin" hidden:"true"`
+	Version                 bool     `short:"v" long:"version" description:"Print TFLint version"`
+	Init                    bool     `long:"init" description:"Install plugins"`
+	Langserver              bool     `long:"langserver" description:"Start language server"`
+	PrintConfig             string   `long:"print-config" description:"Print the effective config and where each rule setting came from" optional:"yes" optional-value:"hcl" choice:"hcl" choice:"json"`
+	ValidateConfig          bool     `long:"validate-config" description:"Validate the config without inspecting"`
+	ConfigSchema            bool     `long:"config-schema" description:"Print a JSON Schema of the config file"`
+	Format                  string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif"`
+	Config                  []string `short:"c" long:"config" description:"Config file name. Can be specified multiple times to merge files in order" value-name:"FILE" default:".tflint.hcl"`
+	ConfigProfile           string   `long:"config-profile" description:"Apply the named profile in config files" value-name:"NAME" env:"TFLINT_PROFILE"`
+	IgnoreModules           []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
+	EnableRules             []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
+	DisableRules            []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
+	Only                    []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
+	EnablePlugins           []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
+	Varfiles                []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
+	Variables               []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
+	Module                  *bool    `long:"module" description:"Enable module inspection"`
+	NoModule                *bool    `long:"no-module" description:"Disable module inspection"`
+	Chdir                   string   `long:"chdir" description:"Switch to a different working directory before executing the command" value-name:"DIR"`
+	Recursive               bool     `long:"recursive" description:"Run command in each directory recursively"`
+	Filter                  []string `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
+	Force                   *bool    `long:"force" description:"Return zero exit status even if issues found"`
+	Baseline                string   `long:"baseline" description:"Report only issues not recorded in the baseline file" value-name:"FILE"`
+	WriteBaseline           string   `long:"write-baseline" description:"Record the current issues to the baseline file" value-name:"FILE"`
+	Fix                     bool     `long:"fix" description:"Fix issues automatically"`
+	Diff                    bool     `long:"diff" description:"Print changes made by --fix as a unified diff without writing files"`
+	Profile                 string   `long:"profile" description:"Print the time spent in inspection to stderr" optional:"yes" optional-value:"table" choice:"table" choice:"json"`
+	ProfileTrace            string   `long:"profile-trace" description:"Write the time spent in inspection to a file in the Chrome trace event format" value-name:"FILE"`
+	MinimumFailureSeverity  string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
+	MaxErrors               *int     `long:"max-errors" description:"Exit with a non-zero error code only if the number of errors exceeds N" value-name:"N"`
+	MaxWarnings             *int     `long:"max-warnings" description:"Exit with a non-zero error code only if the number of warnings exceeds N" value-name:"N"`
+	RequireAnnotationReason bool     `long:"require-annotation-reason" description:"Require a reason for all annotations"`
+	ReportUnusedAnnotations bool     `long:"report-unused-annotations" description:"Report annotations that don't suppress any issues"`
+	Color                   bool     `long:"color" description:"Enable colorized output"`
+	NoColor                 bool     `long:"no-color" description:"Disable colorized output"`
+	ActAsBundledPlugin      bool     `long:"act-as-bundled-plugin" hidden:"true"`
+
+	// configSet is whether --config is passed on the command line, not set by default.
+	configSet bool
+	// rootDir is the directory where TFLint is run. Config files in parent directories
+	// outside a repository are not discovered beyond it.
+	rootDir string
 }
 
 func (opts *Options) toConfig() *tflint.Config {
@@ -71,6 +91,8 @@ func (opts *Options) toConfig() *tflint.Config {
 	log.Printf("[DEBUG]   Module: %t", module)
 	log.Printf("[DEBUG]   Force: %t", force)
 	log.Printf("[DEBUG]   Format: %s", opts.Format)
+	log.Printf("[DEBUG]   RequireAnnotationReason: %t", opts.RequireAnnotationReason)
+	log.Printf("[DEBUG]   ReportUnusedAnnotations: %t", opts.ReportUnusedAnnotations)
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(opts.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(opts.Variables, ", "))
 	log.Printf("[DEBUG]   EnableRules: %s", strings.Join(opts.EnableRules, ", "))
@@ -136,6 +158,12 @@ func (opts *Options) toConfig() *tflint.Config {
 		DisabledByDefault:    len(opts.Only) > 0,
 		DisabledByDefaultSet: len(opts.Only) > 0,
 
+		RequireAnnotationReason:    opts.RequireAnnotationReason,
+		RequireAnnotationReasonSet: opts.RequireAnnotationReason,
+
+		ReportUnusedAnnotations:    opts.ReportUnusedAnnotations,
+		ReportUnusedAnnotationsSet: opts.ReportUnusedAnnotations,
+
 		Varfiles:      varfiles,
 		Variables:     opts.Variables,
 		Only:          opts.Only,
@@ -0,0 +1,563 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint-ruleset-terraform/rules"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/terraform-linters/tflint/tflint"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.org/x/exp/slices"
+)
+
+// effectiveConfig is the merged config of a working directory,
+// with the resolved state of all rules and where they came from.
+type effectiveConfig struct {
+	WorkingDirectory string                   `json:"working_directory"`
+	Files            []string                 `json:"files"`
+	Config           effectiveGlobalConfig    `json:"config"`
+	Plugins          []*effectivePluginConfig `json:"plugins"`
+	Rules            []*effectiveRuleConfig   `json:"rules"`
+	Overrides        []*effectiveOverride     `json:"overrides"`
+	Ignores          []*effectiveIgnore       `json:"ignores"`
+
+	sources map[string][]byte
+}
+
+type effectiveGlobalConfig struct {
+	Module                  bool            `json:"module"`
+	Force                   bool            `json:"force"`
+	DisabledByDefault       bool            `json:"disabled_by_default"`
+	PluginDir               string          `json:"plugin_dir"`
+	Format                  string          `json:"format"`
+	Varfiles                []string        `json:"varfile"`
+	Variables               []string        `json:"variables"`
+	Exclude                 []string        `json:"exclude"`
+	IgnoreModules           map[string]bool `json:"ignore_module"`
+	Only                    []string        `json:"only"`
+	RequireAnnotationReason bool            `json:"require_annotation_reason"`
+	ReportUnusedAnnotations bool            `json:"report_unused_annotations"`
+}
+
+type effectivePluginConfig struct {
+	Name       string                     `json:"name"`
+	Enabled    bool                       `json:"enabled"`
+	Version    string                     `json:"version,omitempty"`
+	Source     string                     `json:"source,omitempty"`
+	SourceType string                     `json:"source_type,omitempty"`
+	Bundled    bool                       `json:"bundled"`
+	Attributes map[string]json.RawMessage `json:"attributes,omitempty"`
+
+	attrs hcl.Attributes
+}
+
+type effectiveRuleConfig struct {
+	Name    string `json:"name"`
+	Ruleset string `json:"ruleset"`
+	// Enabled is nil if the state is determined by the plugin and unknown to TFLint
+	Enabled    *bool                      `json:"enabled"`
+	Source     string                     `json:"source"`
+	Severity   string                     `json:"severity,omitempty"`
+	MaxIssues  *int                       `json:"max_issues,omitempty"`
+	Attributes map[string]json.RawMessage `json:"attributes,omitempty"`
+
+	attrs hcl.Attributes
+}
+
+type effectiveOverride struct {
+	Files []string              `json:"files"`
+	Rules []*effectiveRuleState `json:"rules"`
+}
+
+type effectiveIgnore struct {
+	Rule         string   `json:"rule"`
+	Files        []string `json:"files,omitempty"`
+	MessageRegex string   `json:"message_regex,omitempty"`
+	Address      string   `json:"address,omitempty"`
+	Reason       string   `json:"reason,omitempty"`
+	Until        string   `json:"until,omitempty"`
+}
+
+type effectiveRuleState struct {
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Severity string `json:"severity,omitempty"`
+}
+
+func (cli *CLI) printConfig(opts Options) int {
+	workingDirs, err := findWorkingDirs(opts)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	configs := []*effectiveConfig{}
+	for _, wd := range workingDirs {
+		err := cli.withinChangedDir(wd, func() error {
+			if opts.Recursive {
+				loader, err := terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir)
+				if err != nil {
+					return fmt.Errorf("Failed to prepare loading; %w", err)
+				}
+				// Ignore non-module directories in recursive mode
+				if !loader.IsConfigDir(".") {
+					return nil
+				}
+			}
+
+			config, err := resolveEffectiveConfig(opts)
+			if err != nil {
+				return err
+			}
+			config.WorkingDirectory = wd
+			configs = append(configs, config)
+			return nil
+		})
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
+	switch opts.PrintConfig {
+	case "json":
+		var out interface{} = configs
+		if !opts.Recursive && len(configs) == 1 {
+			out = configs[0]
+		}
+		b, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+		fmt.Fprintln(cli.outStream, string(b))
+	default:
+		for i, config := range configs {
+			if opts.Recursive {
+				if i > 0 {
+					fmt.Fprint(cli.outStream, "\n")
+				}
+				fmt.Fprintf(cli.outStream, "# working directory: %s\n\n", config.WorkingDirectory)
+			}
+			fmt.Fprint(cli.outStream, string(config.HCL()))
+		}
+	}
+
+	return ExitCodeOK
+}
+
+// resolveEffectiveConfig loads the config in the current directory and launches plugins
+// to resolve the state of all rules in the same way as inspection.
+func resolveEffectiveConfig(opts Options) (*effectiveConfig, error) {
+	config, err := setupConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesetPlugin, err := launchPlugins(config)
+	if rulesetPlugin != nil {
+		defer rulesetPlugin.Clean()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bundled := map[string]bool{}
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		version, err := ruleset.RuleSetVersion()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get ruleset version of `%s` plugin; %w", name, err)
+		}
+		bundled[name] = strings.HasSuffix(version, "-bundled")
+	}
+
+	ret := &effectiveConfig{
+		Files: config.Files(),
+		Config: effectiveGlobalConfig{
+			Module:                  config.Module,
+			Force:                   config.Force,
+			DisabledByDefault:       config.DisabledByDefault,
+			PluginDir:               config.PluginDir,
+			Format:                  config.Format,
+			Varfiles:                nonNil(config.Varfiles),
+			Variables:               nonNil(config.Variables),
+			Exclude:                 nonNil(config.Exclude),
+			IgnoreModules:           config.IgnoreModules,
+			Only:                    nonNil(config.Only),
+			RequireAnnotationReason: config.RequireAnnotationReason,
+			ReportUnusedAnnotations: config.ReportUnusedAnnotations,
+		},
+		Plugins:   []*effectivePluginConfig{},
+		Rules:     []*effectiveRuleConfig{},
+		Overrides: []*effectiveOverride{},
+		Ignores:   []*effectiveIgnore{},
+		sources:   config.Sources(),
+	}
+
+	for _, plugin := range config.Plugins {
+		ret.Plugins = append(ret.Plugins, &effectivePluginConfig{
+			Name:       plugin.Name,
+			Enabled:    plugin.Enabled,
+			Version:    plugin.Version,
+			Source:     plugin.Source,
+			SourceType: plugin.SourceType,
+			Bundled:    bundled[plugin.Name],
+			Attributes: attributesToJSON(bodyAttributes(plugin.Body), ret.sources),
+			attrs:      bodyAttributes(plugin.Body),
+		})
+	}
+	sort.Slice(ret.Plugins, func(i, j int) bool { return ret.Plugins[i].Name < ret.Plugins[j].Name })
+
+	presets, err := resolvePresets(config, bundled)
+	if err != nil {
+		return nil, err
+	}
+	for name, ruleset := range config.RuleRulesets() {
+		rule := resolveRule(name, ruleset, config, opts, presets[ruleset], bundled[ruleset])
+		if rc, exists := config.Rules[name]; exists {
+			rule.Severity = rc.Severity
+			rule.MaxIssues = rc.MaxIssues
+			rule.attrs = bodyAttributes(rc.Body)
+			rule.Attributes = attributesToJSON(rule.attrs, ret.sources)
+		}
+		ret.Rules = append(ret.Rules, rule)
+	}
+	sort.Slice(ret.Rules, func(i, j int) bool { return ret.Rules[i].Name < ret.Rules[j].Name })
+
+	for _, override := range config.Overrides {
+		out := &effectiveOverride{Files: override.Files, Rules: []*effectiveRuleState{}}
+		for _, rule := range override.Rules {
+			out.Rules = append(out.Rules, &effectiveRuleState{Name: rule.Name, Enabled: rule.Enabled, Severity: rule.Severity})
+		}
+		sort.Slice(out.Rules, func(i, j int) bool { return out.Rules[i].Name < out.Rules[j].Name })
+		ret.Overrides = append(ret.Overrides, out)
+	}
+
+	for _, ignore := range config.Ignores {
+		out := &effectiveIgnore{Rule: ignore.Rule, Files: ignore.Files, Address: ignore.Address, Reason: ignore.Reason}
+		if ignore.MessageRegex != nil {
+			out.MessageRegex = ignore.MessageRegex.String()
+		}
+		if !ignore.Until.IsZero() {
+			out.Until = ignore.Until.Format("2006-01-02")
+		}
+		ret.Ignores = append(ret.Ignores, out)
+	}
+
+	return ret, nil
+}
+
+// rulesetPreset is the preset declared in the plugin block.
+// Rules is the names of rules in the preset, or nil if unknown.
+type rulesetPreset struct {
+	Source string
+	Rules  []string
+}
+
+// resolvePresets returns the presets declared in plugin blocks.
+// Presets are a feature of the Terraform Language ruleset,
+// and the rules in a preset are known only for the bundled plugin.
+func resolvePresets(config *tflint.Config, bundled map[string]bool) (map[string]*rulesetPreset, error) {
+	ret := map[string]*rulesetPreset{}
+
+	plugin, exists := config.Plugins["terraform"]
+	if !exists || !plugin.Enabled || plugin.Body == nil {
+		return ret, nil
+	}
+	content, _, diags := plugin.Body.PartialContent(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "preset"}}})
+	if diags.HasErrors() {
+		return ret, diags
+	}
+	attr, exists := content.Attributes["preset"]
+	if !exists {
+		return ret, nil
+	}
+	var name string
+	if diags := gohcl.DecodeExpression(attr.Expr, tflint.ConfigEvalContext(attr.Expr.Range().Filename), &name); diags.HasErrors() {
+		return ret, diags
+	}
+
+	preset := &rulesetPreset{Source: fmt.Sprintf("preset %q (%s)", name, rangeToSource(attr.Range))}
+	if plugin.ImplicitPreset() {
+		preset.Source = fmt.Sprintf("bundled preset %q", name)
+	}
+	if bundled["terraform"] {
+		preset.Rules = []string{}
+		for _, rule := range rules.PresetRules[name] {
+			preset.Rules = append(preset.Rules, rule.Name())
+		}
+	}
+	ret["terraform"] = preset
+	return ret, nil
+}
+
+// resolveRule resolves whether the rule is enabled, in the same order as rulesets do:
+//
+//  1. --only option
+//  2. --enable-rule and --disable-rule options, and rule blocks
+//  3. Preset declared in the plugin block (Terraform Language ruleset only)
+//  4. disabled_by_default
+//  5. Default of the rule
+func resolveRule(name string, ruleset string, config *tflint.Config, opts Options, preset *rulesetPreset, bundled bool) *effectiveRuleConfig {
+	rule := &effectiveRuleConfig{Name: name, Ruleset: ruleset}
+
+	if len(config.Only) > 0 {
+		rule.Enabled = boolPtr(slices.Contains(config.Only, name))
+		rule.Source = "--only"
+		return rule
+	}
+
+	if rc, exists := config.Rules[name]; exists {
+		rule.Enabled = boolPtr(rc.Enabled)
+		switch {
+		case slices.Contains(opts.EnableRules, name):
+			rule.Source = "--enable-rule"
+		case slices.Contains(opts.DisableRules, name):
+			rule.Source = "--disable-rule"
+		case rc.Body != nil:
+			rule.Source = rangeToSource(rc.Body.MissingItemRange())
+		default:
+			rule.Source = "CLI flag"
+		}
+		return rule
+	}
+
+	if preset != nil {
+		rule.Source = preset.Source
+		if preset.Rules != nil {
+			rule.Enabled = boolPtr(slices.Contains(preset.Rules, name))
+		}
+		return rule
+	}
+
+	if config.DisabledByDefault {
+		rule.Enabled = boolPtr(false)
+		rule.Source = "disabled_by_default"
+		return rule
+	}
+
+	switch {
+	case ruleset == "core":
+		rule.Enabled = boolPtr(true)
+		rule.Source = "default"
+	case ruleset == "terraform" && bundled:
+		for _, r := range rules.PresetRules["all"] {
+			if r.Name() == name {
+				rule.Enabled = boolPtr(r.Enabled())
+			}
+		}
+		rule.Source = "default"
+	default:
+		rule.Source = "plugin default"
+	}
+	return rule
+}
+
+// HCL returns the config in the HCL format. Where each setting came from is written as comments.
+func (c *effectiveConfig) HCL() []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	appendComment(body, fmt.Sprintf("files: %s", strings.Join(c.Files, ", ")))
+	config := body.AppendNewBlock("config", nil).Body()
+	config.SetAttributeValue("module", cty.BoolVal(c.Config.Module))
+	config.SetAttributeValue("force", cty.BoolVal(c.Config.Force))
+	config.SetAttributeValue("disabled_by_default", cty.BoolVal(c.Config.DisabledByDefault))
+	config.SetAttributeValue("plugin_dir", cty.StringVal(c.Config.PluginDir))
+	config.SetAttributeValue("format", cty.StringVal(c.Config.Format))
+	config.SetAttributeValue("varfile", stringListVal(c.Config.Varfiles))
+	config.SetAttributeValue("variables", stringListVal(c.Config.Variables))
+	config.SetAttributeValue("exclude", stringListVal(c.Config.Exclude))
+	ignoreModules := map[string]cty.Value{}
+	for name, ignore := range c.Config.IgnoreModules {
+		ignoreModules[name] = cty.BoolVal(ignore)
+	}
+	if len(ignoreModules) > 0 {
+		config.SetAttributeValue("ignore_module", cty.MapVal(ignoreModules))
+	} else {
+		config.SetAttributeValue("ignore_module", cty.MapValEmpty(cty.Bool))
+	}
+	config.SetAttributeValue("require_annotation_reason", cty.BoolVal(c.Config.RequireAnnotationReason))
+	config.SetAttributeValue("report_unused_annotations", cty.BoolVal(c.Config.ReportUnusedAnnotations))
+	if len(c.Config.Only) > 0 {
+		appendComment(config, fmt.Sprintf("only: %s", strings.Join(c.Config.Only, ", ")))
+	}
+
+	for _, plugin := range c.Plugins {
+		body.AppendNewline()
+		if plugin.Bundled {
+			appendComment(body, "bundled plugin")
+		}
+		block := body.AppendNewBlock("plugin", []string{plugin.Name}).Body()
+		block.SetAttributeValue("enabled", cty.BoolVal(plugin.Enabled))
+		if plugin.Version != "" {
+			block.SetAttributeValue("version", cty.StringVal(plugin.Version))
+		}
+		if plugin.Source != "" {
+			block.SetAttributeValue("source", cty.StringVal(plugin.Source))
+		}
+		if plugin.SourceType != "" {
+			block.SetAttributeValue("source_type", cty.StringVal(plugin.SourceType))
+		}
+		setRawAttributes(block, plugin.attrs, c.sources)
+	}
+
+	for _, rule := range c.Rules {
+		body.AppendNewline()
+		comment := fmt.Sprintf("ruleset: %s, source: %s", rule.Ruleset, rule.Source)
+		if rule.Enabled == nil {
+			comment += " (enabled state is determined by the plugin)"
+		}
+		appendComment(body, comment)
+		block := body.AppendNewBlock("rule", []string{rule.Name}).Body()
+		if rule.Enabled != nil {
+			block.SetAttributeValue("enabled", cty.BoolVal(*rule.Enabled))
+		}
+		if rule.Severity != "" {
+			block.SetAttributeValue("severity", cty.StringVal(rule.Severity))
+		}
+		if rule.MaxIssues != nil {
+			block.SetAttributeValue("max_issues", cty.NumberIntVal(int64(*rule.MaxIssues)))
+		}
+		setRawAttributes(block, rule.attrs, c.sources)
+	}
+
+	for _, override := range c.Overrides {
+		body.AppendNewline()
+		block := body.AppendNewBlock("override", nil).Body()
+		block.SetAttributeValue("files", stringListVal(override.Files))
+		for _, rule := range override.Rules {
+			block.AppendNewline()
+			ruleBlock := block.AppendNewBlock("rule", []string{rule.Name}).Body()
+			ruleBlock.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+			if rule.Severity != "" {
+				ruleBlock.SetAttributeValue("severity", cty.StringVal(rule.Severity))
+			}
+		}
+	}
+
+	for _, ignore := range c.Ignores {
+		body.AppendNewline()
+		block := body.AppendNewBlock("ignore", nil).Body()
+		block.SetAttributeValue("rule", cty.StringVal(ignore.Rule))
+		if len(ignore.Files) > 0 {
+			block.SetAttributeValue("files", stringListVal(ignore.Files))
+		}
+		for _, attr := range []struct{ name, value string }{
+			{"message_regex", ignore.MessageRegex},
+			{"address", ignore.Address},
+			{"reason", ignore.Reason},
+			{"until", ignore.Until},
+		} {
+			if attr.value != "" {
+				block.SetAttributeValue(attr.name, cty.StringVal(attr.value))
+			}
+		}
+	}
+
+	return hclwrite.Format(f.Bytes())
+}
+
+// bodyAttributes returns attributes in the body. Nested blocks are not supported and return nil.
+func bodyAttributes(body hcl.Body) hcl.Attributes {
+	if body == nil {
+		return nil
+	}
+	attrs, diags := body.JustAttributes()
+	if diags.HasErrors() {
+		return nil
+	}
+	return attrs
+}
+
+// attributesToJSON evaluates attributes as JSON values.
+// Expressions that cannot be evaluated statically are returned as the source text.
+func attributesToJSON(attrs hcl.Attributes, sources map[string][]byte) map[string]json.RawMessage {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	ret := map[string]json.RawMessage{}
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() {
+			if b, err := ctyjson.Marshal(val, val.Type()); err == nil {
+				ret[name] = b
+				continue
+			}
+		}
+		b, _ := json.Marshal(string(expressionSource(attr.Expr, sources)))
+		ret[name] = b
+	}
+	return ret
+}
+
+// setRawAttributes writes attributes in the order of declaration, using the source text of expressions
+func setRawAttributes(body *hclwrite.Body, attrs hcl.Attributes, sources map[string][]byte) {
+	sorted := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Range.Filename != sorted[j].Range.Filename {
+			return sorted[i].Range.Filename < sorted[j].Range.Filename
+		}
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+
+	for _, attr := range sorted {
+		src := expressionSource(attr.Expr, sources)
+		if src == nil {
+			continue
+		}
+		body.SetAttributeRaw(attr.Name, hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: src}})
+	}
+}
+
+func expressionSource(expr hcl.Expression, sources map[string][]byte) []byte {
+	rng := expr.Range()
+	src, exists := sources[rng.Filename]
+	if !exists || rng.End.Byte > len(src) {
+		return nil
+	}
+	return src[rng.Start.Byte:rng.End.Byte]
+}
+
+func appendComment(body *hclwrite.Body, comment string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# " + comment + "\n")}})
+}
+
+func rangeToSource(rng hcl.Range) string {
+	return fmt.Sprintf("%s:%d", rng.Filename, rng.Start.Line)
+}
+
+func stringListVal(list []string) cty.Value {
+	if len(list) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, len(list))
+	for i, v := range list {
+		vals[i] = cty.StringVal(v)
+	}
+	return cty.ListVal(vals)
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
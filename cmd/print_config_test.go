@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_resolveRule(t *testing.T) {
+	file, diags := hclsyntax.ParseConfig([]byte(`
+rule "terraform_typed_variables" {
+  enabled = true
+}`), ".tflint.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ruleBody := file.Body.(*hclsyntax.Body).Blocks[0].Body
+
+	cases := []struct {
+		Name     string
+		Rule     string
+		Ruleset  string
+		Config   *tflint.Config
+		Opts     Options
+		Preset   *rulesetPreset
+		Bundled  bool
+		Expected *effectiveRuleConfig
+	}{
+		{
+			Name:     "--only",
+			Rule:     "terraform_typed_variables",
+			Ruleset:  "terraform",
+			Config:   &tflint.Config{Only: []string{"terraform_unused_declarations"}, Rules: map[string]*tflint.RuleConfig{}},
+			Expected: &effectiveRuleConfig{Name: "terraform_typed_variables", Ruleset: "terraform", Enabled: boolPtr(false), Source: "--only"},
+		},
+		{
+			Name:    "rule block",
+			Rule:    "terraform_typed_variables",
+			Ruleset: "terraform",
+			Config: &tflint.Config{Rules: map[string]*tflint.RuleConfig{
+				"terraform_typed_variables": {Name: "terraform_typed_variables", Enabled: true, Body: ruleBody},
+			}},
+			Preset:   &rulesetPreset{Source: `preset "recommended" (.tflint.hcl:2)`},
+			Expected: &effectiveRuleConfig{Name: "terraform_typed_variables", Ruleset: "terraform", Enabled: boolPtr(true), Source: ".tflint.hcl:2"},
+		},
+		{
+			Name:    "--disable-rule",
+			Rule:    "terraform_typed_variables",
+			Ruleset: "terraform",
+			Config: &tflint.Config{Rules: map[string]*tflint.RuleConfig{
+				"terraform_typed_variables": {Name: "terraform_typed_variables", Enabled: false},
+			}},
+			Opts:     Options{DisableRules: []string{"terraform_typed_variables"}},
+			Expected: &effectiveRuleConfig{Name: "terraform_typed_variables", Ruleset: "terraform", Enabled: boolPtr(false), Source: "--disable-rule"},
+		},
+		{
+			Name:     "bundled preset",
+			Rule:     "terraform_typed_variables",
+			Ruleset:  "terraform",
+			Config:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Preset:   &rulesetPreset{Source: `bundled preset "recommended"`, Rules: []string{"terraform_typed_variables"}},
+			Bundled:  true,
+			Expected: &effectiveRuleConfig{Name: "terraform_typed_variables", Ruleset: "terraform", Enabled: boolPtr(true), Source: `bundled preset "recommended"`},
+		},
+		{
+			Name:     "preset of an installed plugin",
+			Rule:     "terraform_typed_variables",
+			Ruleset:  "terraform",
+			Config:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Preset:   &rulesetPreset{Source: `preset "all" (.tflint.hcl:3)`},
+			Expected: &effectiveRuleConfig{Name: "terraform_typed_variables", Ruleset: "terraform", Source: `preset "all" (.tflint.hcl:3)`},
+		},
+		{
+			Name:     "disabled_by_default",
+			Rule:     "aws_instance_invalid_type",
+			Ruleset:  "aws",
+			Config:   &tflint.Config{DisabledByDefault: true, Rules: map[string]*tflint.RuleConfig{}},
+			Expected: &effectiveRuleConfig{Name: "aws_instance_invalid_type", Ruleset: "aws", Enabled: boolPtr(false), Source: "disabled_by_default"},
+		},
+		{
+			Name:     "core rule",
+			Rule:     "terraform_unused_annotation",
+			Ruleset:  "core",
+			Config:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: &effectiveRuleConfig{Name: "terraform_unused_annotation", Ruleset: "core", Enabled: boolPtr(true), Source: "default"},
+		},
+		{
+			Name:     "bundled plugin default",
+			Rule:     "terraform_naming_convention",
+			Ruleset:  "terraform",
+			Config:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Bundled:  true,
+			Expected: &effectiveRuleConfig{Name: "terraform_naming_convention", Ruleset: "terraform", Enabled: boolPtr(true), Source: "default"},
+		},
+		{
+			Name:     "plugin default",
+			Rule:     "aws_instance_invalid_type",
+			Ruleset:  "aws",
+			Config:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: &effectiveRuleConfig{Name: "aws_instance_invalid_type", Ruleset: "aws", Source: "plugin default"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := resolveRule(tc.Rule, tc.Ruleset, tc.Config, tc.Opts, tc.Preset, tc.Bundled)
+			if diff := cmp.Diff(tc.Expected, got, cmpopts.IgnoreUnexported(effectiveRuleConfig{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// printProfile prints the summary of the profile to stderr, so as not to break
+// the output of formats like JSON, and writes the trace file if --profile-trace is set.
+func (cli *CLI) printProfile(opts Options) error {
+	if cli.profiler == nil {
+		return nil
+	}
+
+	summary := cli.profiler.Summary()
+	switch opts.Profile {
+	case "table":
+		w := tabwriter.NewWriter(cli.errStream, 0, 0, 2, ' ', 0)
+		printProfileEntries(w, "Ruleset", summary.Rulesets)
+		printProfileEntries(w, "Module", summary.Modules)
+		printProfileEntries(w, "RPC", summary.RPCs)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	case "json":
+		out, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cli.errStream, string(out))
+	}
+
+	if opts.ProfileTrace != "" {
+		f, err := os.Create(opts.ProfileTrace)
+		if err != nil {
+			return fmt.Errorf("Failed to write the profile trace; %w", err)
+		}
+		defer f.Close()
+
+		if err := cli.profiler.WriteTrace(f); err != nil {
+			return fmt.Errorf("Failed to write the profile trace; %w", err)
+		}
+	}
+	return nil
+}
+
+func printProfileEntries(w *tabwriter.Writer, title string, entries []*tflint.ProfileEntry) {
+	fmt.Fprintf(w, "%s\tCalls\tTotal\tMax\n", title)
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", entry.Name, entry.Count, entry.Total.Round(time.Microsecond), entry.Max.Round(time.Microsecond))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func (cli *CLI) validateConfig(opts Options) int {
+	workingDirs, err := findWorkingDirs(opts)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	valid := true
+	for _, wd := range workingDirs {
+		err := cli.withinChangedDir(wd, func() error {
+			if opts.Recursive {
+				loader, err := terraform.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir)
+				if err != nil {
+					return fmt.Errorf("Failed to prepare loading; %w", err)
+				}
+				// Ignore non-module directories in recursive mode
+				if !loader.IsConfigDir(".") {
+					return nil
+				}
+			}
+
+			config, err := setupConfig(opts)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, diagnosticSources(err))
+				valid = false
+				return nil
+			}
+			// Launch plugins to validate plugin configs against their schemas and rule names
+			rulesetPlugin, err := launchPlugins(config)
+			if rulesetPlugin != nil {
+				defer rulesetPlugin.Clean()
+			}
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, config.Sources())
+				valid = false
+				return nil
+			}
+
+			if diags := config.ValidateRuleBodies(); len(diags) > 0 {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Found problems in rule config; %w", diags), config.Sources())
+				if diags.HasErrors() {
+					valid = false
+					return nil
+				}
+			}
+
+			if opts.Recursive {
+				fmt.Fprintf(cli.outStream, "%s: Config is valid\n", wd)
+			} else {
+				fmt.Fprintln(cli.outStream, "Config is valid")
+			}
+			return nil
+		})
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
+	if !valid {
+		return ExitCodeError
+	}
+	return ExitCodeOK
+}
+
+// diagnosticSources reads the files that the diagnostics refer to.
+// This is used to show source code of config files that failed to load.
+func diagnosticSources(err error) map[string][]byte {
+	sources := map[string][]byte{}
+
+	var diags hcl.Diagnostics
+	if !errors.As(err, &diags) {
+		return sources
+	}
+	for _, diag := range diags {
+		if diag.Subject == nil {
+			continue
+		}
+		if _, exists := sources[diag.Subject.Filename]; exists {
+			continue
+		}
+		if src, err := os.ReadFile(diag.Subject.Filename); err == nil {
+			sources[diag.Subject.Filename] = src
+		}
+	}
+	return sources
+}
+
+func (cli *CLI) printConfigSchema(opts Options) int {
+	config, err := setupConfig(opts)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	// Launch plugins to list rules and plugin configs in the schema
+	rulesetPlugin, err := launchPlugins(config)
+	if rulesetPlugin != nil {
+		defer rulesetPlugin.Clean()
+	}
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	pluginSchemas := map[string]*hclext.BodySchema{}
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		schema, err := ruleset.ConfigSchema()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to fetch config schema from `%s` plugin; %w", name, err), map[string][]byte{})
+			return ExitCodeError
+		}
+		pluginSchemas[name] = schema
+	}
+
+	out, err := json.MarshalIndent(tflint.ConfigJSONSchema(config.RuleRulesets(), pluginSchemas), "", "  ")
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+	fmt.Fprintln(cli.outStream, string(out))
+
+	return ExitCodeOK
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint/plugin"
 	"github.com/terraform-linters/tflint/tflint"
 )
@@ -49,7 +48,7 @@ func (cli *CLI) printVersion(opts Options) int {
 
 func getPluginVersions(opts Options) []string {
 	// Load configuration files to print plugin versions
-	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cfg, err := loadConfig(opts)
 	if err != nil {
 		log.Printf("[ERROR] Failed to load TFLint config: %s", err)
 		return []string{}
@@ -8,12 +8,14 @@ import (
 )
 
 type checkstyleError struct {
-	Rule     string `xml:"rule,attr"`
-	Line     int    `xml:"line,attr"`
-	Column   int    `xml:"column,attr"`
-	Severity string `xml:"severity,attr"`
-	Message  string `xml:"message,attr"`
-	Link     string `xml:"link,attr"`
+	Rule        string `xml:"rule,attr"`
+	Line        int    `xml:"line,attr"`
+	Column      int    `xml:"column,attr"`
+	Severity    string `xml:"severity,attr"`
+	Message     string `xml:"message,attr"`
+	Link        string `xml:"link,attr"`
+	Fingerprint string `xml:"fingerprint,attr,omitempty"`
+	Address     string `xml:"address,attr,omitempty"`
 }
 
 type checkstyleFile struct {
@@ -30,12 +32,14 @@ func (f *Formatter) checkstylePrint(issues tflint.Issues, appErr error, sources
 	files := map[string]*checkstyleFile{}
 	for _, issue := range issues {
 		cherr := &checkstyleError{
-			Rule:     issue.Rule.Name(),
-			Line:     issue.Range.Start.Line,
-			Column:   issue.Range.Start.Column,
-			Severity: toSeverity(issue.Rule.Severity()),
-			Message:  issue.Message,
-			Link:     issue.Rule.Link(),
+			Rule:        issue.Rule.Name(),
+			Line:        issue.Range.Start.Line,
+			Column:      issue.Range.Start.Column,
+			Severity:    toSeverity(issue.Rule.Severity()),
+			Message:     issue.Message,
+			Link:        issue.Rule.Link(),
+			Fingerprint: issue.Fingerprint,
+			Address:     issue.Address,
 		}
 
 		if file, exists := files[issue.Range.Filename]; exists {
@@ -39,6 +39,28 @@ func Test_checkstylePrint(t *testing.T) {
   <file name="test.tf">
     <error rule="test_rule" line="1" column="1" severity="error" message="test" link="https://github.com"></error>
   </file>
+</checkstyle>`,
+		},
+		{
+			Name: "issues with fingerprints and addresses",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fingerprint: "0123456789abcdef0123456789abcdef",
+					Address:     "aws_instance.web",
+				},
+			},
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle>
+  <file name="test.tf">
+    <error rule="test_rule" line="1" column="1" severity="error" message="test" link="https://github.com" fingerprint="0123456789abcdef0123456789abcdef" address="aws_instance.web"></error>
+  </file>
 </checkstyle>`,
 		},
 	}
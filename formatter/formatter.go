@@ -17,23 +17,24 @@ type Formatter struct {
 	NoColor bool
 }
 
-// Print outputs the given issues and errors according to configured format
+// Print outputs the given issues and errors according to configured format.
+// Suppressed issues are only output in formats that can represent suppressions (JSON and SARIF).
 func (f *Formatter) Print(issues tflint.Issues, err error, sources map[string][]byte) {
 	switch f.Format {
 	case "default":
-		f.prettyPrint(issues, err, sources)
+		f.prettyPrint(issues.Unsuppressed(), err, sources)
 	case "json":
 		f.jsonPrint(issues, err)
 	case "checkstyle":
-		f.checkstylePrint(issues, err, sources)
+		f.checkstylePrint(issues.Unsuppressed(), err, sources)
 	case "junit":
-		f.junitPrint(issues, err, sources)
+		f.junitPrint(issues.Unsuppressed(), err, sources)
 	case "compact":
-		f.compactPrint(issues, err, sources)
+		f.compactPrint(issues.Unsuppressed(), err, sources)
 	case "sarif":
 		f.sarifPrint(issues, err)
 	default:
-		f.prettyPrint(issues, err, sources)
+		f.prettyPrint(issues.Unsuppressed(), err, sources)
 	}
 }
 
@@ -12,12 +12,30 @@ import (
 
 // JSONIssue is a temporary structure for converting TFLint issues to JSON.
 type JSONIssue struct {
-	Rule    JSONRule    `json:"rule"`
-	Message string      `json:"message"`
-	Range   JSONRange   `json:"range"`
+	Rule        JSONRule               `json:"rule"`
+	Message     string                 `json:"message"`
+	Range       JSONRange              `json:"range"`
+	Callers     []JSONRange            `json:"callers"`
+	Fingerprint string                 `json:"fingerprint,omitempty"`
+	Address     string                 `json:"address,omitempty"`
+	Instances   []JSONInstance         `json:"instances,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Remediation string                 `json:"remediation,omitempty"`
+	Suppression *JSONSuppression       `json:"suppression,omitempty"`
+}
+
+// JSONInstance is a temporary structure for converting module instances that emitted issues to JSON.
+type JSONInstance struct {
+	Module  string      `json:"module"`
 	Callers []JSONRange `json:"callers"`
 }
 
+// JSONSuppression is a temporary structure for converting suppressions to JSON.
+type JSONSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
 // JSONRule is a temporary structure for converting TFLint rules to JSON.
 type JSONRule struct {
 	Name     string `json:"name"`
@@ -48,35 +66,17 @@ type JSONError struct {
 
 // JSONOutput is a temporary structure for converting to JSON.
 type JSONOutput struct {
-	Issues []JSONIssue `json:"issues"`
-	Errors []JSONError `json:"errors"`
+	Issues           []JSONIssue `json:"issues"`
+	SuppressedIssues []JSONIssue `json:"suppressed_issues,omitempty"`
+	Errors           []JSONError `json:"errors"`
 }
 
 func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
-	ret := &JSONOutput{Issues: make([]JSONIssue, len(issues)), Errors: []JSONError{}}
+	ret := &JSONOutput{Errors: []JSONError{}}
 
-	for idx, issue := range issues.Sort() {
-		ret.Issues[idx] = JSONIssue{
-			Rule: JSONRule{
-				Name:     issue.Rule.Name(),
-				Severity: toSeverity(issue.Rule.Severity()),
-				Link:     issue.Rule.Link(),
-			},
-			Message: issue.Message,
-			Range: JSONRange{
-				Filename: issue.Range.Filename,
-				Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
-				End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
-			},
-			Callers: make([]JSONRange, len(issue.Callers)),
-		}
-		for i, caller := range issue.Callers {
-			ret.Issues[idx].Callers[i] = JSONRange{
-				Filename: caller.Filename,
-				Start:    JSONPos{Line: caller.Start.Line, Column: caller.Start.Column},
-				End:      JSONPos{Line: caller.End.Line, Column: caller.End.Column},
-			}
-		}
+	ret.Issues = toJSONIssues(issues.Unsuppressed())
+	if suppressed := issues.Suppressed(); len(suppressed) > 0 {
+		ret.SuppressedIssues = toJSONIssues(suppressed)
 	}
 
 	if appErr != nil {
@@ -109,3 +109,54 @@ func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
 	}
 	fmt.Fprint(f.Stdout, string(out))
 }
+
+func toJSONIssues(issues tflint.Issues) []JSONIssue {
+	ret := make([]JSONIssue, len(issues))
+
+	for idx, issue := range issues.Sort() {
+		ret[idx] = JSONIssue{
+			Rule: JSONRule{
+				Name:     issue.Rule.Name(),
+				Severity: toSeverity(issue.Rule.Severity()),
+				Link:     issue.Rule.Link(),
+			},
+			Message: issue.Message,
+			Range: JSONRange{
+				Filename: issue.Range.Filename,
+				Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
+				End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
+			},
+			Callers:     make([]JSONRange, len(issue.Callers)),
+			Fingerprint: issue.Fingerprint,
+			Address:     issue.Address,
+			Metadata:    issue.Metadata,
+			Remediation: issue.Remediation,
+		}
+		for i, caller := range issue.Callers {
+			ret[idx].Callers[i] = toJSONRange(caller)
+		}
+		for _, instance := range issue.Instances {
+			callers := make([]JSONRange, len(instance.Callers))
+			for i, caller := range instance.Callers {
+				callers[i] = toJSONRange(caller)
+			}
+			ret[idx].Instances = append(ret[idx].Instances, JSONInstance{Module: instance.Module.String(), Callers: callers})
+		}
+		if issue.Suppression != nil {
+			ret[idx].Suppression = &JSONSuppression{
+				Kind:          string(issue.Suppression.Kind),
+				Justification: issue.Suppression.Justification,
+			}
+		}
+	}
+
+	return ret
+}
+
+func toJSONRange(rng hcl.Range) JSONRange {
+	return JSONRange{
+		Filename: rng.Filename,
+		Start:    JSONPos{Line: rng.Start.Line, Column: rng.Start.Column},
+		End:      JSONPos{Line: rng.End.Line, Column: rng.End.Column},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/terraform/addrs"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -46,6 +47,106 @@ func Test_jsonPrint(t *testing.T) {
 			),
 			Stdout: `{"issues":[],"errors":[{"summary":"summary","message":"detail","severity":"warning","range":{"filename":"filename","start":{"line":1,"column":1},"end":{"line":5,"column":1}}}]}`,
 		},
+		{
+			Name: "suppressed issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Suppression: &tflint.Suppression{
+						Kind:          tflint.SuppressionInSource,
+						Justification: "legacy AMI",
+					},
+				},
+			},
+			Stdout: `{"issues":[],"suppressed_issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[],"suppression":{"kind":"inSource","justification":"legacy AMI"}}],"errors":[]}`,
+		},
+		{
+			Name: "issues with fingerprints and addresses",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Fingerprint: "0123456789abcdef0123456789abcdef",
+					Address:     `module.app["a"].aws_instance.web`,
+				},
+			},
+			Stdout: `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[],"fingerprint":"0123456789abcdef0123456789abcdef","address":"module.app[\"a\"].aws_instance.web"}],"errors":[]}`,
+		},
+		{
+			Name: "issues with module instances",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+					},
+					Callers: []hcl.Range{
+						{
+							Filename: "main.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+						},
+					},
+					Instances: []*tflint.IssueInstance{
+						{
+							Module: addrs.RootModuleInstance.Child("app", addrs.StringKey("a")),
+							Callers: []hcl.Range{
+								{
+									Filename: "main.tf",
+									Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+									End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+								},
+							},
+						},
+						{
+							Module: addrs.RootModuleInstance.Child("app", addrs.StringKey("b")),
+							Callers: []hcl.Range{
+								{
+									Filename: "main.tf",
+									Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+									End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+								},
+							},
+						},
+					},
+				},
+			},
+			Stdout: `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"main.tf","start":{"line":2,"column":3},"end":{"line":2,"column":6}},"callers":[{"filename":"main.tf","start":{"line":2,"column":3},"end":{"line":2,"column":6}}],"instances":[{"module":"module.app[\"a\"]","callers":[{"filename":"main.tf","start":{"line":2,"column":3},"end":{"line":2,"column":6}}]},{"module":"module.app[\"b\"]","callers":[{"filename":"main.tf","start":{"line":2,"column":3},"end":{"line":2,"column":6}}]}]}],"errors":[]}`,
+		},
+		{
+			Name: "issues with metadata and remediation",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Metadata: map[string]interface{}{
+						"control": "CIS-2.1",
+						"paths":   []string{"instance_type"},
+					},
+					Remediation: "Use a current generation instance type",
+				},
+			},
+			Stdout: `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[],"metadata":{"control":"CIS-2.1","paths":["instance_type"]},"remediation":"Use a current generation instance type"}],"errors":[]}`,
+		},
 	}
 
 	for _, tc := range cases {
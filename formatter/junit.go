@@ -1,8 +1,10 @@
 package formatter
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"sort"
 
 	"github.com/jstemmer/go-junit-report/formatter"
 	"github.com/terraform-linters/tflint/tflint"
@@ -10,11 +12,43 @@ import (
 
 // https://www.ibm.com/docs/en/developer-for-zos/14.1.0?topic=formats-junit-xml-format
 
+// junitTestSuites is the same as formatter.JUnitTestSuites,
+// but test cases can have properties to output issue metadata.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName    xml.Name                  `xml:"testsuite"`
+	Tests      int                       `xml:"tests,attr"`
+	Failures   int                       `xml:"failures,attr"`
+	Time       string                    `xml:"time,attr"`
+	Name       string                    `xml:"name,attr"`
+	Properties []formatter.JUnitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase           `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	XMLName    xml.Name                `xml:"testcase"`
+	Classname  string                  `xml:"classname,attr"`
+	Name       string                  `xml:"name,attr"`
+	Time       string                  `xml:"time,attr"`
+	Properties *junitProperties        `xml:"properties,omitempty"`
+	Failure    *formatter.JUnitFailure `xml:"failure,omitempty"`
+}
+
+// junitProperties is a pointer wrapper of properties, as "properties>property,omitempty"
+// still outputs an empty parent element.
+type junitProperties struct {
+	Properties []formatter.JUnitProperty `xml:"property"`
+}
+
 func (f *Formatter) junitPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
-	cases := make([]formatter.JUnitTestCase, len(issues))
+	cases := make([]junitTestCase, len(issues))
 
 	for i, issue := range issues.Sort() {
-		cases[i] = formatter.JUnitTestCase{
+		cases[i] = junitTestCase{
 			Name:      issue.Rule.Name(),
 			Classname: issue.Range.Filename,
 			Time:      "0",
@@ -29,11 +63,12 @@ func (f *Formatter) junitPrint(issues tflint.Issues, appErr error, sources map[s
 					issue.Range,
 				),
 			},
+			Properties: toJUnitProperties(issue),
 		}
 	}
 
-	suites := formatter.JUnitTestSuites{
-		Suites: []formatter.JUnitTestSuite{
+	suites := junitTestSuites{
+		Suites: []junitTestSuite{
 			{
 				Time:      "0",
 				Tests:     len(issues),
@@ -54,3 +89,35 @@ func (f *Formatter) junitPrint(issues tflint.Issues, appErr error, sources map[s
 		f.prettyPrintErrors(appErr, sources)
 	}
 }
+
+// toJUnitProperties converts the metadata and remediation of the issue to properties.
+// Properties are sorted by name, and non-string values are encoded as JSON.
+// It returns nil if there are no properties.
+func toJUnitProperties(issue *tflint.Issue) *junitProperties {
+	names := make([]string, 0, len(issue.Metadata))
+	for name := range issue.Metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var properties []formatter.JUnitProperty
+	for _, name := range names {
+		value, ok := issue.Metadata[name].(string)
+		if !ok {
+			out, err := json.Marshal(issue.Metadata[name])
+			if err != nil {
+				continue
+			}
+			value = string(out)
+		}
+		properties = append(properties, formatter.JUnitProperty{Name: name, Value: value})
+	}
+	if issue.Remediation != "" {
+		properties = append(properties, formatter.JUnitProperty{Name: "remediation", Value: issue.Remediation})
+	}
+
+	if len(properties) == 0 {
+		return nil
+	}
+	return &junitProperties{Properties: properties}
+}
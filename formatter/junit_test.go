@@ -47,6 +47,39 @@ func Test_junitPrint(t *testing.T) {
       <failure message="test.tf:1,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test.tf:1,1-4</failure>
     </testcase>
   </testsuite>
+</testsuites>`,
+		},
+		{
+			Name: "issues with metadata and remediation",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "issue message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Metadata: map[string]interface{}{
+						"paths":   []string{"instance_type"},
+						"control": "CIS-2.1",
+					},
+					Remediation: "Use a current generation instance type",
+				},
+			},
+			Stdout: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite tests="1" failures="1" time="0" name="">
+    <properties></properties>
+    <testcase classname="test.tf" name="test_rule" time="0">
+      <properties>
+        <property name="control" value="CIS-2.1"></property>
+        <property name="paths" value="[&#34;instance_type&#34;]"></property>
+        <property name="remediation" value="Use a current generation instance type"></property>
+      </properties>
+      <failure message="test.tf:1,1-4: issue message" type="Error">Error: issue message&#xA;Rule: test_rule&#xA;Range: test.tf:1,1-4</failure>
+    </testcase>
+  </testsuite>
 </testsuites>`,
 		},
 	}
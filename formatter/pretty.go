@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclparse"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
+	"golang.org/x/exp/slices"
 )
 
 var colorBold = color.New(color.Bold).SprintfFunc()
@@ -74,6 +75,10 @@ func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[
 		}
 	}
 
+	if issue.Remediation != "" {
+		fmt.Fprintf(f.Stdout, "\nRemediation: %s\n", issue.Remediation)
+	}
+
 	if len(issue.Callers) > 0 {
 		fmt.Fprint(f.Stdout, "\nCallers:\n")
 		for _, caller := range issue.Callers {
@@ -81,6 +86,20 @@ func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[
 		}
 	}
 
+	if len(issue.Instances) > 0 {
+		fmt.Fprint(f.Stdout, "\nModule instances:\n")
+		for _, instance := range issue.Instances {
+			fmt.Fprintf(f.Stdout, "   %s\n", instance.Module)
+			// Show callers only if they are different from the above
+			if slices.Equal(instance.Callers, issue.Callers) {
+				continue
+			}
+			for _, caller := range instance.Callers {
+				fmt.Fprintf(f.Stdout, "      %s\n", caller)
+			}
+		}
+	}
+
 	if issue.Rule.Link() != "" {
 		fmt.Fprintf(f.Stdout, "\nReference: %s\n", issue.Rule.Link())
 	}
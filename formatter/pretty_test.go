@@ -8,6 +8,7 @@ import (
 
 	"github.com/fatih/color"
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/terraform/addrs"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -69,6 +70,117 @@ Callers:
 
 Reference: https://github.com
 
+`,
+		},
+		{
+			Name: "issues with module instances",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Callers: []hcl.Range{
+						{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+						},
+						{
+							Filename: "module.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+						},
+					},
+					Instances: []*tflint.IssueInstance{
+						{
+							Module: addrs.RootModuleInstance.Child("app", addrs.StringKey("a")),
+							Callers: []hcl.Range{
+								{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+									End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+								},
+								{
+									Filename: "module.tf",
+									Start:    hcl.Pos{Line: 2, Column: 3, Byte: 0},
+									End:      hcl.Pos{Line: 2, Column: 6, Byte: 3},
+								},
+							},
+						},
+						{
+							Module: addrs.RootModuleInstance.Child("app", addrs.StringKey("b")),
+							Callers: []hcl.Range{
+								{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+									End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+								},
+								{
+									Filename: "module.tf",
+									Start:    hcl.Pos{Line: 8, Column: 3, Byte: 0},
+									End:      hcl.Pos{Line: 8, Column: 6, Byte: 3},
+								},
+							},
+						},
+					},
+				},
+			},
+			Sources: map[string][]byte{
+				"test.tf": []byte("foo = 1"),
+			},
+			Stdout: `1 issue(s) found:
+
+Error: test (test_rule)
+
+  on test.tf line 1:
+   1: foo = 1
+
+Callers:
+   test.tf:1,1-4
+   module.tf:2,3-6
+
+Module instances:
+   module.app["a"]
+   module.app["b"]
+      test.tf:1,1-4
+      module.tf:8,3-6
+
+Reference: https://github.com
+
+`,
+		},
+		{
+			Name: "issues with remediation",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Remediation: "Use a current generation instance type",
+				},
+			},
+			Sources: map[string][]byte{
+				"test.tf": []byte("foo = 1"),
+			},
+			Stdout: `1 issue(s) found:
+
+Error: test (test_rule)
+
+  on test.tf line 1:
+   1: foo = 1
+
+Remediation: Use a current generation instance type
+
+Reference: https://github.com
+
 `,
 		},
 		{
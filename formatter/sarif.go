@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/owenrumney/go-sarif/sarif"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
+// sarifFingerprintKey is the key of the fingerprint in partialFingerprints.
+// The version suffix must be changed when the fingerprint calculation is changed.
+const sarifFingerprintKey = "tflint/v1"
+
 func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 	report, initErr := sarif.New(sarif.Version210)
 	if initErr != nil {
@@ -60,7 +65,32 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 			WithMessage(sarif.NewTextMessage(issue.Message))
 
 		if location != nil {
-			result.WithLocation(sarif.NewLocationWithPhysicalLocation(location))
+			loc := sarif.NewLocationWithPhysicalLocation(location)
+			if issue.Address != "" {
+				loc.LogicalLocations = []*sarif.LogicalLocation{
+					sarif.NewLogicalLocation().WithFullyQualifiedName(issue.Address),
+				}
+			}
+			result.WithLocation(loc)
+		}
+
+		if issue.Fingerprint != "" {
+			result.WithPartialFingerPrints(map[string]interface{}{sarifFingerprintKey: issue.Fingerprint})
+		}
+
+		if len(issue.Metadata) > 0 || issue.Remediation != "" {
+			properties := sarif.Properties{}
+			for key, value := range issue.Metadata {
+				properties[key] = value
+			}
+			if issue.Remediation != "" {
+				properties["remediation"] = issue.Remediation
+			}
+			result.WithProperties(properties)
+		}
+
+		if issue.Suppression != nil {
+			result.WithSuppression(toSarifSuppression(issue))
 		}
 	}
 
@@ -102,3 +132,30 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 		panic(stdoutErr)
 	}
 }
+
+// toSarifSuppression converts the suppression of the issue to SARIF.
+// All properties are set because go-sarif outputs unset properties as null,
+// which is not allowed in the SARIF schema. The GUID is derived from the issue
+// and the suppression so that it is stable across runs.
+func toSarifSuppression(issue *tflint.Issue) *sarif.Suppression {
+	suppressionRange := issue.Suppression.Range
+	location := sarif.NewPhysicalLocation().
+		WithArtifactLocation(sarif.NewSimpleArtifactLocation(filepath.ToSlash(suppressionRange.Filename)))
+	if !suppressionRange.Empty() {
+		location.WithRegion(
+			sarif.NewRegion().
+				WithStartLine(suppressionRange.Start.Line).
+				WithStartColumn(suppressionRange.Start.Column).
+				WithEndLine(suppressionRange.End.Line).
+				WithEndColumn(suppressionRange.End.Column),
+		)
+	}
+
+	guid := uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s:%s:%s", issue.Rule.Name(), issue.Range, suppressionRange)))
+
+	return sarif.NewSuppression(string(issue.Suppression.Kind)).
+		WithStatus("accepted").
+		WithLocation(sarif.NewLocationWithPhysicalLocation(location)).
+		WithGuid(guid.String()).
+		WithJustifcation(issue.Suppression.Justification)
+}
@@ -105,8 +105,10 @@ func TestIntegration(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			// Fingerprints are opaque hashes tested in unit tests
 			opts := []cmp.Option{
 				cmpopts.IgnoreFields(formatter.JSONRule{}, "Link"),
+				cmpopts.IgnoreFields(formatter.JSONIssue{}, "Fingerprint"),
 			}
 			if diff := cmp.Diff(got, expected, opts...); diff != "" {
 				t.Error(diff)
@@ -189,6 +189,43 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeError,
 			stderr:  "Rule not found: nosuchrule",
 		},
+		{
+			name:    "`--validate-config` option",
+			command: "./tflint --validate-config",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			stdout:  "Config is valid",
+		},
+		{
+			name:    "`--validate-config` option with a misspelled rule name",
+			command: "./tflint --validate-config --enable-rule aws_instance_example_typ",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "Rule not found: aws_instance_example_typ. Did you mean `aws_instance_example_type`?",
+		},
+		{
+			name:    "`--validate-config` option with invalid rule config",
+			command: "./tflint --validate-config",
+			dir:     "invalid_config",
+			status:  cmd.ExitCodeError,
+			stderr:  `The rule "tflint_unused_annotation" does not accept an argument named "foo".`,
+		},
+		{
+			name:    "`--config-schema` option",
+			command: "./tflint --config-schema",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			stdout: `    "rule": {
+      "additionalProperties": {`,
+		},
+		{
+			name:    "`--config-schema` option lists plugin rules",
+			command: "./tflint --config-schema",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			stdout: `"aws_instance_example_type": {
+          "description": "Rule of the testing ruleset",`,
+		},
 		{
 			name:    "issues found",
 			command: "./tflint",
@@ -196,6 +233,48 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeIssuesFound,
 			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
 		},
+		{
+			name:    "without `--config-profile` option",
+			command: "./tflint",
+			dir:     "profiles",
+			status:  cmd.ExitCodeOK,
+			stdout:  "",
+		},
+		{
+			name:    "`--config-profile` option",
+			command: "./tflint --config-profile ci",
+			dir:     "profiles",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "`--config-profile` option with a misspelled profile",
+			command: "./tflint --config-profile cl",
+			dir:     "profiles",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to load TFLint config; profile `cl` is not declared. Did you mean `ci`?",
+		},
+		{
+			name:    "`--only` option with a rule pattern",
+			command: "./tflint --only aws_instance_*",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "`--disable-rule` option with a ruleset-qualified pattern",
+			command: "./tflint --disable-rule testing:*",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  "",
+		},
+		{
+			name:    "`--only` option with an unmatched rule pattern",
+			command: "./tflint --only google_*",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeError,
+			stderr:  "Failed to check rule config; No rules match the pattern: google_*",
+		},
 		{
 			name:    "`--force` option with issues",
 			command: "./tflint --force",
@@ -224,6 +303,100 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeOK,
 			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
 		},
+		{
+			name:    "`--max-warnings` option with warnings within the budget",
+			command: "./tflint --max-warnings=1",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+		},
+		{
+			name:    "`--max-warnings` option with warnings over the budget",
+			command: "./tflint --max-warnings=0",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_s3_bucket_with_config_example)", color.New(color.Bold).Sprint("bucket name is test, config=bucket")),
+			stderr:  "Budget exceeded for warnings: 1 issue(s) found, 0 allowed",
+		},
+		{
+			name:    "`--max-warnings` option with errors",
+			command: "./tflint --max-warnings=1",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "`--max-errors` option with errors within the budget",
+			command: "./tflint --max-errors=1",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "`--max-errors` option with a negative value",
+			command: "./tflint --max-errors=-1",
+			dir:     "issues_found",
+			status:  cmd.ExitCodeError,
+			stderr:  "--max-errors and --max-warnings must be zero or more",
+		},
+		{
+			name:    "`--fix` and `--diff` options",
+			command: "./tflint --report-unused-annotations --fix --diff",
+			dir:     "fixes",
+			status:  cmd.ExitCodeIssuesFound,
+			stdout: `--- a/main.tf
++++ b/main.tf
+@@ -1,2 +1 @@
+-# tflint-ignore: aws_instance_example_type
+ variable "name" {}
+`,
+		},
+		{
+			name:    "`--diff` option without `--fix`",
+			command: "./tflint --diff",
+			dir:     "fixes",
+			status:  cmd.ExitCodeError,
+			stderr:  "Cannot use --diff without --fix",
+		},
+		{
+			name:    "rule budget",
+			command: "./tflint",
+			dir:     "rule_budgets",
+			status:  cmd.ExitCodeOK,
+			stdout:  fmt.Sprintf("%s (aws_instance_example_type)", color.New(color.Bold).Sprint("instance type is t2.micro")),
+		},
+		{
+			name:    "`--print-config` option",
+			command: "./tflint --print-config",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout: `# ruleset: testing, source: .tflint.hcl:5
+rule "aws_s3_bucket_with_config_example" {
+  enabled = true
+  name    = "bucket"
+}`,
+		},
+		{
+			name:    "`--print-config` option with plugin defaults",
+			command: "./tflint --print-config",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout: `# ruleset: testing, source: plugin default (enabled state is determined by the plugin)
+rule "aws_instance_example_type" {
+}`,
+		},
+		{
+			name:    "`--print-config` option with --disable-rule",
+			command: "./tflint --print-config=json --disable-rule aws_instance_example_type",
+			dir:     "warnings_found",
+			status:  cmd.ExitCodeOK,
+			stdout: `{
+      "name": "aws_instance_example_type",
+      "ruleset": "testing",
+      "enabled": false,
+      "source": "--disable-rule"
+    }`,
+		},
 		{
 			name:    "`--no-color` option",
 			command: "./tflint --no-color",
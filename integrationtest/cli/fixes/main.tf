@@ -0,0 +1,2 @@
+# tflint-ignore: aws_instance_example_type
+variable "name" {}
@@ -0,0 +1,8 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "tflint_unused_annotation" {
+  enabled = true
+  foo     = "bar"
+}
@@ -0,0 +1,13 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_instance_example_type" {
+  enabled = false
+}
+
+profile "ci" {
+  rule "aws_instance_example_type" {
+    enabled = true
+  }
+}
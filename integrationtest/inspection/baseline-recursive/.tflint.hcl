@@ -0,0 +1,7 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "tflint_unused_baseline_entry" {
+  enabled = false
+}
@@ -0,0 +1,9 @@
+# Lines are shifted after the baseline is recorded
+
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "baz" {
+  instance_type = "t2.micro"
+}
@@ -0,0 +1,4 @@
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_example_type -- until=2000-01-01
+  instance_type = "t2.micro"
+}
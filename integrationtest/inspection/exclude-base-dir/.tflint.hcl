@@ -0,0 +1,8 @@
+config {
+  # Patterns are relative to this file, even in subdirectories
+  exclude = ["sub/gen.tf", "sub/legacy/"]
+}
+
+plugin "testing" {
+  enabled = true
+}
@@ -0,0 +1,7 @@
+config {
+  exclude = ["*.generated.tf"]
+}
+
+plugin "testing" {
+  enabled = true
+}
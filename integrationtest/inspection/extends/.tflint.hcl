@@ -0,0 +1,6 @@
+extends = ["policy/base.tflint.hcl"]
+
+rule "aws_s3_bucket_with_config_example" {
+  enabled  = true
+  severity = "error"
+}
@@ -0,0 +1,23 @@
+config {
+  report_unused_annotations = true
+}
+
+plugin "testing" {
+  enabled = true
+}
+
+ignore {
+  rule   = "aws_instance_example_type"
+  files  = ["*.generated.tf"]
+  reason = "generated by the code generator"
+}
+
+ignore {
+  rule    = "aws_instance_example_type"
+  address = "aws_instance.legacy"
+}
+
+ignore {
+  rule          = "aws_instance_example_type"
+  message_regex = "t3\\."
+}
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/terraform-linters/tflint/cmd"
 	"github.com/terraform-linters/tflint/formatter"
 	"github.com/terraform-linters/tflint/tflint"
@@ -50,6 +51,11 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json --module --ignore-module ./ignore_module",
 			Dir:     "module",
 		},
+		{
+			Name:    "module instances",
+			Command: "./tflint --format json --module",
+			Dir:     "module_instances",
+		},
 		{
 			Name:    "without_module_init",
 			Command: "./tflint --format json",
@@ -138,6 +144,30 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json",
 			Dir:     "rule-config",
 		},
+		{
+			Name:    "config functions",
+			Command: "./tflint --format json",
+			Env: map[string]string{
+				"BUCKET_PREFIX": "CI",
+				"TF_WORKSPACE":  "staging",
+			},
+			Dir: "config-functions",
+		},
+		{
+			Name:    "JSON config",
+			Command: "./tflint --format json",
+			Dir:     "config-json",
+		},
+		{
+			Name:    "extends",
+			Command: "./tflint --format json",
+			Dir:     "extends",
+		},
+		{
+			Name:    "multiple configs",
+			Command: "./tflint --format json --config .tflint.hcl --config ci.tflint.hcl",
+			Dir:     "multiple-configs",
+		},
 		{
 			Name:    "disabled rules",
 			Command: "./tflint --format json",
@@ -213,6 +243,81 @@ func TestIntegration(t *testing.T) {
 			Command: "tflint --recursive --format json",
 			Dir:     "recursive",
 		},
+		{
+			Name:    "recursive with config hierarchy",
+			Command: "tflint --recursive --format json",
+			Dir:     "recursive-hierarchy",
+		},
+		{
+			Name:    "recursive with explicit config",
+			Command: "tflint --recursive --config .tflint.hcl --format json",
+			Dir:     "recursive-explicit-config",
+		},
+		{
+			Name:    "provider locks",
+			Command: "./tflint --format json",
+			Dir:     "provider-locks",
+		},
+		{
+			Name:    "exclude",
+			Command: "./tflint --recursive --format json",
+			Dir:     "exclude",
+		},
+		{
+			Name:    "exclude relative to config files",
+			Command: "./tflint --recursive --format json",
+			Dir:     "exclude-base-dir",
+		},
+		{
+			Name:    "exclude relative to config files in parent directories",
+			Command: "./tflint --recursive --format json",
+			Dir:     "exclude-base-dir/sub",
+		},
+		{
+			Name:    "unused annotations",
+			Command: "./tflint --report-unused-annotations --format json",
+			Dir:     "unused-annotations",
+		},
+		{
+			Name:    "baseline",
+			Command: "./tflint --baseline .tflint-baseline.json --format json",
+			Dir:     "baseline",
+		},
+		{
+			Name:    "baseline in recursive mode",
+			Command: "./tflint --recursive --baseline .tflint-baseline.json --format json",
+			Dir:     "baseline-recursive",
+		},
+		{
+			Name:    "rule severity",
+			Command: "tflint --recursive --format json",
+			Dir:     "rule-severity",
+		},
+		{
+			Name:    "disable core rules with a glob",
+			Command: "./tflint --disable-rule tflint_* --format json",
+			Dir:     "core-rule-patterns",
+		},
+		{
+			Name:    "disable core rules with a ruleset name",
+			Command: "./tflint --disable-rule core:* --format json",
+			Dir:     "core-rule-patterns",
+		},
+		{
+			Name:    "disable core rules with a glob in config",
+			Command: "./tflint --config disabled.hcl --format json",
+			Dir:     "core-rule-patterns",
+		},
+		{
+			Name:    "overrides",
+			Command: "./tflint --format json",
+			Dir:     "overrides",
+		},
+		{
+			Name:    "ignores",
+			Command: "./tflint --format json",
+			Dir:     "ignores",
+		},
 	}
 
 	// Disable the bundled plugin because the `os.Executable()` is go(1) in the tests
@@ -270,7 +375,9 @@ func TestIntegration(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(got, expected); diff != "" {
+			// Fingerprints are opaque hashes tested in unit tests
+			opt := cmpopts.IgnoreFields(formatter.JSONIssue{}, "Fingerprint")
+			if diff := cmp.Diff(got, expected, opt); diff != "" {
 				t.Fatal(diff)
 			}
 		})
@@ -0,0 +1,7 @@
+module "instances" {
+  source = "./module"
+
+  for_each = toset(["a", "b"])
+
+  instance_type = "t1.2xlarge"
+}
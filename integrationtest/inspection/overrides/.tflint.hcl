@@ -0,0 +1,20 @@
+plugin "testing" {
+  enabled = true
+}
+
+override {
+  files = ["legacy.tf"]
+
+  rule "aws_instance_example_type" {
+    enabled = false
+  }
+}
+
+override {
+  files = ["*.generated.tf"]
+
+  rule "aws_instance_example_type" {
+    enabled  = true
+    severity = "notice"
+  }
+}
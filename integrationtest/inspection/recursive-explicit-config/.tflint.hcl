@@ -0,0 +1,8 @@
+plugin "testing" {
+  enabled = true
+}
+
+rule "aws_s3_bucket_with_config_example" {
+  enabled = true
+  name    = "root"
+}
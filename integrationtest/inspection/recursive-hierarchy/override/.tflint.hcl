@@ -0,0 +1,4 @@
+rule "aws_s3_bucket_with_config_example" {
+  enabled = true
+  name    = "override"
+}
@@ -0,0 +1,14 @@
+resource "aws_instance" "foo" {
+  // tflint-ignore: aws_instance_example_type
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "bar" {
+  // tflint-ignore: aws_instance_example_type
+  ami = "ami-12345678"
+}
+
+resource "aws_instance" "baz" {
+  // tflint-ignore: aws_instance_exmple_type
+  instance_type = "t2.micro"
+}
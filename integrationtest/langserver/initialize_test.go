@@ -2,10 +2,13 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	lsp "github.com/sourcegraph/go-lsp"
 )
 
 func Test_initialize(t *testing.T) {
@@ -31,6 +34,75 @@ func Test_initialize(t *testing.T) {
 	})
 }
 
+func Test_initialize_profile(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		content := `resource "aws_instance" "foo" {
+    instance_type = "t1.2xlarge"
+}`
+
+		config := `
+plugin "testing" {
+    enabled = true
+}
+
+profile "editor" {
+    rule "aws_instance_example_type" {
+        enabled  = true
+        severity = "warning"
+    }
+}`
+
+		if err := os.WriteFile(dir+"/main.tf", []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(dir+"/.tflint.hcl", []byte(config), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		uri := pathToURI(dir + "/main.tf")
+
+		stdin, stdout, plugin := startServer(t, dir+"/.tflint.hcl")
+		defer plugin.Clean()
+
+		go func() {
+			fmt.Fprint(stdin, toJSONRPC2(`{"id":0,"method":"initialize","params":{"initializationOptions":{"profile":"editor"}},"jsonrpc":"2.0"}`))
+			fmt.Fprint(stdin, didOpenRequest(uri, content, t))
+			fmt.Fprint(stdin, shutdownRequest())
+			fmt.Fprint(stdin, exitRequest())
+		}()
+
+		buf := new(bytes.Buffer)
+		if _, err := buf.ReadFrom(stdout); err != nil {
+			t.Fatal(err)
+		}
+
+		// The severity is overridden by the profile selected by the client
+		res, err := json.Marshal(jsonrpcMessage{
+			Method: "textDocument/publishDiagnostics",
+			Params: lsp.PublishDiagnosticsParams{
+				URI: uri,
+				Diagnostics: []lsp.Diagnostic{
+					{
+						Message:  `instance type is t1.2xlarge`,
+						Severity: lsp.Warning,
+						Range: lsp.Range{
+							Start: lsp.Position{Line: 1, Character: 20},
+							End:   lsp.Position{Line: 1, Character: 32},
+						},
+					},
+				},
+			},
+			JSONRPC: "2.0",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := initializeResponse() + toJSONRPC2(string(res)) + emptyResponse()
+		if !cmp.Equal(expected, buf.String()) {
+			t.Fatalf("Diff: %s", cmp.Diff(expected, buf.String()))
+		}
+	})
+}
+
 func initializeRequest() string {
 	return toJSONRPC2(`{"id":0,"method":"initialize","params":{},"jsonrpc":"2.0"}`)
 }
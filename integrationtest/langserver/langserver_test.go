@@ -42,7 +42,7 @@ func TestMain(m *testing.M) {
 }
 
 func startServer(t *testing.T, configPath string) (io.Writer, io.Reader, *plugin.Plugin) {
-	handler, plugin, err := langserver.NewHandler(configPath, tflint.EmptyConfig())
+	handler, plugin, err := langserver.NewHandler([]string{configPath}, "", tflint.EmptyConfig())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -25,9 +25,11 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// NewHandler returns a new JSON-RPC handler
-func NewHandler(configPath string, cliConfig *tflint.Config) (jsonrpc2.Handler, *plugin.Plugin, error) {
-	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, configPath)
+// NewHandler returns a new JSON-RPC handler.
+// If the profile is not empty, it is applied whenever the config files are loaded.
+// Clients can select another profile with the "profile" initialization option.
+func NewHandler(configPaths []string, profile string, cliConfig *tflint.Config) (jsonrpc2.Handler, *plugin.Plugin, error) {
+	cfg, err := loadConfig(configPaths, profile)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,28 +73,72 @@ func NewHandler(configPath string, cliConfig *tflint.Config) (jsonrpc2.Handler,
 
 		rulesets = append(rulesets, ruleset)
 	}
+	if err := cliConfig.ExpandRulePatterns(rulesets...); err != nil {
+		return nil, nil, err
+	}
 	if err := cliConfig.ValidateRules(rulesets...); err != nil {
 		return nil, nil, err
 	}
+	if err := cfg.ExpandRulePatterns(rulesets...); err != nil {
+		return nil, nil, err
+	}
 
 	return jsonrpc2.HandlerWithError((&handler{
-		configPath:        configPath,
+		configPaths:       configPaths,
+		profile:           profile,
 		cliConfig:         cliConfig,
 		config:            cfg,
 		fs:                afero.NewCopyOnWriteFs(afero.NewOsFs(), afero.NewMemMapFs()),
 		plugin:            rulsetPlugin,
+		rulesets:          rulesets,
 		clientSDKVersions: clientSDKVersions,
 		diagsPaths:        []string{},
 	}).handle), rulsetPlugin, nil
 }
 
+func loadConfig(configPaths []string, profile string) (*tflint.Config, error) {
+	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, configPaths...)
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		if err := cfg.ApplyProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// reloadConfig loads the config files again with the passed profile,
+// and merges the config from CLI flags. The profile is kept for later reloads.
+func (h *handler) reloadConfig(profile string) error {
+	cfg, err := loadConfig(h.configPaths, profile)
+	if err != nil {
+		return err
+	}
+	if h.cliConfig.DisabledByDefault {
+		for _, rule := range cfg.Rules {
+			rule.Enabled = false
+		}
+	}
+	cfg.Merge(h.cliConfig)
+	if err := cfg.ExpandRulePatterns(h.rulesets...); err != nil {
+		return err
+	}
+	h.profile = profile
+	h.config = cfg
+	return nil
+}
+
 type handler struct {
-	configPath        string
+	configPaths       []string
+	profile           string
 	cliConfig         *tflint.Config
 	config            *tflint.Config
 	fs                afero.Fs
 	rootDir           string
 	plugin            *plugin.Plugin
+	rulesets          []tflint.RuleSet
 	clientSDKVersions map[string]*version.Version
 	shutdown          bool
 	diagsPaths        []string
@@ -122,7 +168,7 @@ func (h *handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 
 	switch req.Method {
 	case "initialize":
-		return initialize(ctx, conn, req)
+		return h.initialize(ctx, conn, req)
 	case "initialized":
 		return nil, nil
 	case "shutdown":
@@ -164,6 +210,21 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 	if err != nil {
 		return ret, fmt.Errorf("Failed to prepare loading: %w", err)
 	}
+	excludes := terraform.NewExcludes()
+	if err := excludes.LoadIgnoreFile(afero.Afero{Fs: h.fs}, ".", "."); err != nil {
+		return ret, fmt.Errorf("Failed to load %s: %w", terraform.IgnoreFilename, err)
+	}
+	baseDirs := h.config.ExcludeBaseDirs()
+	for i, pattern := range h.config.Exclude {
+		baseDir := baseDirs[i]
+		if baseDir == "" {
+			baseDir = h.rootDir
+		}
+		if err := excludes.AddAt(h.rootDir, baseDir, pattern); err != nil {
+			return ret, fmt.Errorf("Failed to parse `exclude`: %w", err)
+		}
+	}
+	loader.SetExcludes(excludes)
 
 	configs, diags := loader.LoadConfig(".", h.config.Module)
 	if diags.HasErrors() {
@@ -175,9 +236,6 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 	}
 	annotations := map[string]tflint.Annotations{}
 	for path, file := range files {
-		if !strings.HasSuffix(path, ".tf") {
-			continue
-		}
 		ants, lexDiags := tflint.NewAnnotations(path, file)
 		diags = diags.Extend(lexDiags)
 		annotations[path] = ants
@@ -193,10 +251,19 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 	}
 	variables = append(variables, cliVars)
 
+	locks, diags := loader.LoadProviderLocks(".")
+	if diags.HasErrors() {
+		return ret, fmt.Errorf("Failed to load the dependency lock file: %w", diags)
+	}
+
 	runner, err := tflint.NewRunner(h.rootDir, h.config, annotations, configs, variables...)
 	if err != nil {
 		return ret, fmt.Errorf("Failed to initialize a runner: %w", err)
 	}
+	runner.ProviderLocks = locks
+	runner.Excludes = excludes
+	runner.CheckProviderLocks()
+	runner.CheckAnnotations()
 	runners, err := tflint.NewModuleRunners(runner)
 	if err != nil {
 		return ret, fmt.Errorf("Failed to prepare rule checking: %w", err)
@@ -231,6 +298,7 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 			}
 		}
 	}
+	runner.CheckUnusedAnnotations()
 
 	// In order to publish that the issue has been fixed,
 	// notify also the path where the past diagnostics were published.
@@ -2,12 +2,39 @@ package langserver
 
 import (
 	"context"
+	"encoding/json"
 
 	lsp "github.com/sourcegraph/go-lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
 
-func initialize(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
+// initializationOptions is the server-specific options passed by clients in the `initialize` request.
+type initializationOptions struct {
+	// Profile is the name of the config profile to apply.
+	// It takes precedence over the profile passed when starting the server.
+	Profile string `json:"profile"`
+}
+
+func (h *handler) initialize(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
+	if req.Params != nil {
+		var params struct {
+			InitializationOptions *initializationOptions `json:"initializationOptions"`
+		}
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, &jsonrpc2.Error{
+				Code:    jsonrpc2.CodeParseError,
+				Message: err.Error(),
+				Data:    req.Params,
+			}
+		}
+
+		if params.InitializationOptions != nil && params.InitializationOptions.Profile != "" {
+			if err := h.reloadConfig(params.InitializationOptions.Profile); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return lsp.InitializeResult{
 		Capabilities: lsp.ServerCapabilities{
 			TextDocumentSync: &lsp.TextDocumentSyncOptionsOrKind{
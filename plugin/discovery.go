@@ -13,6 +13,7 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/mitchellh/go-homedir"
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin/host2plugin"
+	"github.com/terraform-linters/tflint/terraform/didyoumean"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -42,6 +43,9 @@ func Discovery(config *tflint.Config) (*Plugin, error) {
 					if err != nil {
 						return nil, err
 					}
+					if suggestion := didyoumean.NameSuggestion(pluginCfg.Name, installedPluginNames(pluginDir)); suggestion != "" {
+						return nil, fmt.Errorf("Plugin `%s` not found in %s. Did you mean `%s`?", pluginCfg.Name, pluginDir, suggestion)
+					}
 					return nil, fmt.Errorf("Plugin `%s` not found in %s", pluginCfg.Name, pluginDir)
 				}
 				return nil, fmt.Errorf("Plugin `%s` not found. Did you run `tflint --init`?", pluginCfg.Name)
@@ -147,6 +151,23 @@ func checkPluginExistance(path string) (string, error) {
 	return path, nil
 }
 
+// installedPluginNames returns the names of plugins installed manually in the plugin directory.
+func installedPluginNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []string{}
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "tflint-ruleset-") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "tflint-ruleset-"), ".exe"))
+	}
+	return names
+}
+
 func pluginClientError(err error, config *tflint.PluginConfig) error {
 	if err == nil {
 		return nil
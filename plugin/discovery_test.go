@@ -197,6 +197,34 @@ func Test_Discovery_notFound(t *testing.T) {
 	}
 }
 
+func Test_Discovery_notFoundWithSuggestion(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := PluginRoot
+	PluginRoot = filepath.Join(cwd, "test-fixtures", "plugins")
+	defer func() { PluginRoot = original }()
+
+	_, err = Discovery(&tflint.Config{
+		Plugins: map[string]*tflint.PluginConfig{
+			"fooo": {
+				Name:    "fooo",
+				Enabled: true,
+			},
+		},
+	})
+
+	if err == nil {
+		t.Fatal("The error should have occurred, but didn't")
+	}
+	expected := fmt.Sprintf("Plugin `fooo` not found in %s. Did you mean `foo`?", PluginRoot)
+	if err.Error() != expected {
+		t.Fatalf("The error message is not matched: want=%s, got=%s", expected, err.Error())
+	}
+}
+
 func Test_Discovery_plugin_name_is_directory(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
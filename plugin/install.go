@@ -6,14 +6,12 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
-	"github.com/google/go-github/v35/github"
 	"github.com/terraform-linters/tflint/tflint"
-	"golang.org/x/oauth2"
 )
 
 // InstallConfig is a config for plugin installation.
@@ -42,7 +40,19 @@ func (c *InstallConfig) InstallPath() string {
 	return filepath.Join(c.Source, c.Version, fmt.Sprintf("tflint-ruleset-%s", c.Name))
 }
 
-// TagName returns a tag name that the GitHub release should meet.
+// SourceHost returns the hostname of the source, like "github.com".
+func (c *InstallConfig) SourceHost() string {
+	host, _, _ := strings.Cut(c.Source, "/")
+	return host
+}
+
+// SourcePath returns the path of the source without the hostname, like "terraform-linters/tflint-ruleset-aws".
+func (c *InstallConfig) SourcePath() string {
+	_, path, _ := strings.Cut(c.Source, "/")
+	return path
+}
+
+// TagName returns a tag name that the release should meet.
 // The version must not contain leading "v", as the prefix "v" is added here,
 // and the release tag must be in a format similar to `v1.1.1`.
 func (c *InstallConfig) TagName() string {
@@ -55,7 +65,8 @@ func (c *InstallConfig) AssetName() string {
 	return fmt.Sprintf("tflint-ruleset-%s_%s_%s.zip", c.Name, runtime.GOOS, runtime.GOARCH)
 }
 
-// Install fetches the release from GitHub and puts the binary in the plugin directory.
+// Install fetches the release from the source and puts the binary in the plugin directory.
+// See NewReleaseProvider for the supported hosting services.
 // This installation process will automatically check the checksum of the downloaded zip file.
 // Therefore, the release must always contain a checksum file.
 // In addition, the release must meet the following conventions:
@@ -83,13 +94,17 @@ func (c *InstallConfig) Install() (string, error) {
 		return "", fmt.Errorf("Failed to mkdir to %s: %w", filepath.Dir(path), err)
 	}
 
-	assets, err := c.fetchReleaseAssets()
+	provider, err := NewReleaseProvider(c)
+	if err != nil {
+		return "", fmt.Errorf("Failed to fetch releases: %w", err)
+	}
+	assets, err := provider.FetchAssets(context.Background(), c.TagName())
 	if err != nil {
-		return "", fmt.Errorf("Failed to fetch GitHub releases: %w", err)
+		return "", fmt.Errorf("Failed to fetch releases from %s: %w", c.SourceHost(), err)
 	}
 
 	log.Printf("[DEBUG] Download checksums.txt")
-	checksumsFile, err := c.downloadToTempFile(assets["checksums.txt"])
+	checksumsFile, err := downloadToTempFile(provider, assets["checksums.txt"])
 	if checksumsFile != nil {
 		defer os.Remove(checksumsFile.Name())
 	}
@@ -100,7 +115,7 @@ func (c *InstallConfig) Install() (string, error) {
 	sigchecker := NewSignatureChecker(c)
 	if sigchecker.HasSigningKey() {
 		log.Printf("[DEBUG] Download checksums.txt.sig")
-		signatureFile, err := c.downloadToTempFile(assets["checksums.txt.sig"])
+		signatureFile, err := downloadToTempFile(provider, assets["checksums.txt.sig"])
 		if signatureFile != nil {
 			defer os.Remove(signatureFile.Name())
 		}
@@ -118,7 +133,7 @@ func (c *InstallConfig) Install() (string, error) {
 	}
 
 	log.Printf("[DEBUG] Download %s", c.AssetName())
-	zipFile, err := c.downloadToTempFile(assets[c.AssetName()])
+	zipFile, err := downloadToTempFile(provider, assets[c.AssetName()])
 	if zipFile != nil {
 		defer os.Remove(zipFile.Name())
 	}
@@ -143,42 +158,18 @@ func (c *InstallConfig) Install() (string, error) {
 	return path, nil
 }
 
-// fetchReleaseAssets fetches assets from the GitHub release.
-// The release is determined by the source path and tag name.
-func (c *InstallConfig) fetchReleaseAssets() (map[string]*github.ReleaseAsset, error) {
-	assets := map[string]*github.ReleaseAsset{}
-
-	ctx := context.Background()
-	client := newGitHubClient(ctx)
-
-	log.Printf("[DEBUG] Request to https://api.github.com/repos/%s/%s/releases/tags/%s", c.SourceOwner, c.SourceRepo, c.TagName())
-	release, _, err := client.Repositories.GetReleaseByTag(ctx, c.SourceOwner, c.SourceRepo, c.TagName())
-	if err != nil {
-		return assets, err
-	}
-
-	for _, asset := range release.Assets {
-		log.Printf("[DEBUG] asset found: %s", asset.GetName())
-		assets[asset.GetName()] = asset
-	}
-	return assets, nil
-}
-
-// downloadToTempFile download assets from GitHub to a local temp file.
+// downloadToTempFile download assets from the release to a local temp file.
 // It is the caller's responsibility to delete the generated the temp file.
-func (c *InstallConfig) downloadToTempFile(asset *github.ReleaseAsset) (*os.File, error) {
+func downloadToTempFile(provider ReleaseProvider, asset *ReleaseAsset) (*os.File, error) {
 	if asset == nil {
-		return nil, fmt.Errorf("file not found in the GitHub release. Does the release contain the file with the correct name ?")
+		return nil, fmt.Errorf("file not found in the release. Does the release contain the file with the correct name ?")
 	}
 
-	ctx := context.Background()
-	client := newGitHubClient(ctx)
-
-	log.Printf("[DEBUG] Request to https://api.github.com/repos/%s/%s/releases/assets/%d", c.SourceOwner, c.SourceRepo, asset.GetID())
-	downloader, _, err := client.Repositories.DownloadReleaseAsset(ctx, c.SourceOwner, c.SourceRepo, asset.GetID(), http.DefaultClient)
+	downloader, err := provider.Download(context.Background(), asset)
 	if err != nil {
 		return nil, err
 	}
+	defer downloader.Close()
 
 	file, err := os.CreateTemp("", "tflint-download-temp-file-*")
 	if err != nil {
@@ -187,7 +178,6 @@ func (c *InstallConfig) downloadToTempFile(asset *github.ReleaseAsset) (*os.File
 	if _, err = io.Copy(file, downloader); err != nil {
 		return file, err
 	}
-	downloader.Close()
 	if _, err := file.Seek(0, 0); err != nil {
 		return file, err
 	}
@@ -237,20 +227,6 @@ func extractFileFromZipFile(zipFile *os.File, savePath string) error {
 	return nil
 }
 
-func newGitHubClient(ctx context.Context) *github.Client {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return github.NewClient(nil)
-	}
-
-	log.Printf("[DEBUG] GITHUB_TOKEN set, plugin requests to the GitHub API will be authenticated")
-
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	return github.NewClient(oauth2.NewClient(ctx, ts))
-}
-
 func fileExt() string {
 	if runtime.GOOS == "windows" {
 		return ".exe"
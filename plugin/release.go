@@ -0,0 +1,119 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ReleaseProvider fetches plugin releases from a hosting service.
+// The provider is selected by the source type of the plugin config.
+type ReleaseProvider interface {
+	// FetchAssets fetches the release tagged with the name and returns the assets in it, keyed by the file name.
+	FetchAssets(ctx context.Context, tagName string) (map[string]*ReleaseAsset, error)
+	// Download returns the content of the asset. It is the caller's responsibility to close it.
+	Download(ctx context.Context, asset *ReleaseAsset) (io.ReadCloser, error)
+}
+
+// ReleaseAsset is a file attached to the release.
+type ReleaseAsset struct {
+	Name string
+	// ID is the asset ID on GitHub. Assets are downloaded via the API with the ID.
+	ID int64
+	// URL is the download URL of the asset on other services.
+	URL string
+}
+
+// httpClient is the client used to send requests to hosting services.
+// This can be replaced in tests to trust local test servers.
+var httpClient = http.DefaultClient
+
+// NewReleaseProvider returns a new ReleaseProvider for the source of the passed InstallConfig.
+// The following types of hosting services are supported:
+//
+//   - github: GitHub and GitHub Enterprise Server. The API is served at https://api.github.com/ or https://{host}/api/v3/.
+//   - gitlab: GitLab.com and self-managed GitLab. Assets are release links, served by the API at https://{host}/api/v4/.
+//   - http: Plain HTTP servers. Assets are served at https://{host}/{path}/{tag}/{name}.
+//
+// Requests are authenticated with the token in the TFLINT_TOKEN_{host} environment variable.
+// See sourceToken for the details.
+func NewReleaseProvider(config *InstallConfig) (ReleaseProvider, error) {
+	switch config.EffectiveSourceType() {
+	case "github":
+		return newGitHubReleaseProvider(config)
+	case "gitlab":
+		return newGitLabReleaseProvider(config), nil
+	case "http":
+		return newHTTPReleaseProvider(config), nil
+	default:
+		return nil, fmt.Errorf("Unknown source type: %s", config.EffectiveSourceType())
+	}
+}
+
+// sourceToken returns the token to authenticate requests to the host.
+// The token is read from the environment variable named TFLINT_TOKEN_ followed by the hostname,
+// with dots and colons replaced with underscores and hyphens replaced with double underscores.
+// For example, the token for ghe.example-corp.com is read from TFLINT_TOKEN_ghe_example__corp_com.
+// For github.com, GITHUB_TOKEN is also used for compatibility.
+func sourceToken(host string) string {
+	name := "TFLINT_TOKEN_" + strings.NewReplacer(".", "_", ":", "_", "-", "__").Replace(host)
+	if token := os.Getenv(name); token != "" {
+		log.Printf("[DEBUG] %s set, plugin requests to %s will be authenticated", name, host)
+		return token
+	}
+	if host == "github.com" {
+		if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+			log.Printf("[DEBUG] GITHUB_TOKEN set, plugin requests to the GitHub API will be authenticated")
+			return token
+		}
+	}
+	return ""
+}
+
+// getURL sends a GET request to the URL and returns the response body.
+// The authorization header is sent only to the source host, so that credentials
+// don't leak to other hosts, such as storage services that assets are served from.
+func getURL(ctx context.Context, rawURL string, host string, header string, value string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" && req.URL.Host == host {
+		req.Header.Set(header, value)
+	}
+
+	client := *httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		if req.URL.Host != host {
+			req.Header.Del(header)
+		}
+		return nil
+	}
+
+	log.Printf("[DEBUG] Request to %s", redactURL(req.URL))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", redactURL(req.URL), resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// redactURL returns the URL without the query, as signed URLs contain credentials in the query.
+func redactURL(u *url.URL) string {
+	ret := *u
+	ret.RawQuery = ""
+	ret.User = nil
+	return ret.String()
+}
@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/google/go-github/v35/github"
+	"golang.org/x/oauth2"
+)
+
+// gitHubReleaseProvider fetches releases from GitHub or GitHub Enterprise Server.
+type gitHubReleaseProvider struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+var _ ReleaseProvider = (*gitHubReleaseProvider)(nil)
+
+func newGitHubReleaseProvider(config *InstallConfig) (*gitHubReleaseProvider, error) {
+	client := httpClient
+	if token := sourceToken(config.SourceHost()); token != "" {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
+		client = oauth2.NewClient(ctx, ts)
+	}
+
+	provider := &gitHubReleaseProvider{owner: config.SourceOwner, repo: config.SourceRepo}
+	if config.SourceHost() == "github.com" {
+		provider.client = github.NewClient(client)
+		return provider, nil
+	}
+
+	host := config.SourceHost()
+	var err error
+	provider.client, err = github.NewEnterpriseClient(fmt.Sprintf("https://%s/api/v3/", host), fmt.Sprintf("https://%s/api/uploads/", host), client)
+	if err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// FetchAssets fetches assets from the GitHub release.
+// The release is determined by the source path and tag name.
+func (p *gitHubReleaseProvider) FetchAssets(ctx context.Context, tagName string) (map[string]*ReleaseAsset, error) {
+	assets := map[string]*ReleaseAsset{}
+
+	log.Printf("[DEBUG] Request to %srepos/%s/%s/releases/tags/%s", p.client.BaseURL, p.owner, p.repo, tagName)
+	release, _, err := p.client.Repositories.GetReleaseByTag(ctx, p.owner, p.repo, tagName)
+	if err != nil {
+		return assets, err
+	}
+
+	for _, asset := range release.Assets {
+		log.Printf("[DEBUG] asset found: %s", asset.GetName())
+		assets[asset.GetName()] = &ReleaseAsset{Name: asset.GetName(), ID: asset.GetID()}
+	}
+	return assets, nil
+}
+
+// Download downloads the asset via the GitHub API.
+// Redirects to the storage are followed without credentials.
+func (p *gitHubReleaseProvider) Download(ctx context.Context, asset *ReleaseAsset) (io.ReadCloser, error) {
+	log.Printf("[DEBUG] Request to %srepos/%s/%s/releases/assets/%d", p.client.BaseURL, p.owner, p.repo, asset.ID)
+	downloader, _, err := p.client.Repositories.DownloadReleaseAsset(ctx, p.owner, p.repo, asset.ID, httpClient)
+	return downloader, err
+}
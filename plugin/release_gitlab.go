@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+)
+
+// gitLabReleaseProvider fetches releases from GitLab.com or self-managed GitLab.
+// Assets are the links attached to the release, such as generic packages.
+type gitLabReleaseProvider struct {
+	host    string
+	project string
+	token   string
+}
+
+var _ ReleaseProvider = (*gitLabReleaseProvider)(nil)
+
+func newGitLabReleaseProvider(config *InstallConfig) *gitLabReleaseProvider {
+	return &gitLabReleaseProvider{
+		host:    config.SourceHost(),
+		project: config.SourcePath(),
+		token:   sourceToken(config.SourceHost()),
+	}
+}
+
+// gitLabRelease is the response of the GitLab Releases API.
+// https://docs.gitlab.com/ee/api/releases/#get-a-release-by-a-tag-name
+type gitLabRelease struct {
+	Assets struct {
+		Links []struct {
+			Name           string `json:"name"`
+			URL            string `json:"url"`
+			DirectAssetURL string `json:"direct_asset_url"`
+		} `json:"links"`
+	} `json:"assets"`
+}
+
+// FetchAssets fetches the links of the GitLab release.
+// The release is determined by the project path and tag name.
+func (p *gitLabReleaseProvider) FetchAssets(ctx context.Context, tagName string) (map[string]*ReleaseAsset, error) {
+	assets := map[string]*ReleaseAsset{}
+
+	endpoint := fmt.Sprintf("https://%s/api/v4/projects/%s/releases/%s", p.host, url.PathEscape(p.project), url.PathEscape(tagName))
+	body, err := getURL(ctx, endpoint, p.host, "PRIVATE-TOKEN", p.token)
+	if err != nil {
+		return assets, err
+	}
+	defer body.Close()
+
+	var release gitLabRelease
+	if err := json.NewDecoder(body).Decode(&release); err != nil {
+		return assets, fmt.Errorf("Failed to decode the release: %w", err)
+	}
+
+	for _, link := range release.Assets.Links {
+		log.Printf("[DEBUG] asset found: %s", link.Name)
+		asset := &ReleaseAsset{Name: link.Name, URL: link.DirectAssetURL}
+		if asset.URL == "" {
+			asset.URL = link.URL
+		}
+		assets[link.Name] = asset
+	}
+	return assets, nil
+}
+
+// Download downloads the asset from the link URL.
+// The token is sent only if the link refers to the GitLab host.
+func (p *gitLabReleaseProvider) Download(ctx context.Context, asset *ReleaseAsset) (io.ReadCloser, error) {
+	return getURL(ctx, asset.URL, p.host, "PRIVATE-TOKEN", p.token)
+}
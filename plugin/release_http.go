@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// httpReleaseProvider fetches releases from a plain HTTP server.
+// The server has no API to list assets, so assets are assumed to be served
+// under the directory named after the tag, like https://{host}/{path}/v1.0.0/checksums.txt.
+type httpReleaseProvider struct {
+	host      string
+	baseURL   string
+	assetName string
+	token     string
+}
+
+var _ ReleaseProvider = (*httpReleaseProvider)(nil)
+
+func newHTTPReleaseProvider(config *InstallConfig) *httpReleaseProvider {
+	return &httpReleaseProvider{
+		host:      config.SourceHost(),
+		baseURL:   fmt.Sprintf("https://%s", config.Source),
+		assetName: config.AssetName(),
+		token:     sourceToken(config.SourceHost()),
+	}
+}
+
+// FetchAssets returns the assets that the release should contain by the conventions.
+// Whether the assets exist is checked when downloading them.
+func (p *httpReleaseProvider) FetchAssets(ctx context.Context, tagName string) (map[string]*ReleaseAsset, error) {
+	assets := map[string]*ReleaseAsset{}
+	for _, name := range []string{"checksums.txt", "checksums.txt.sig", p.assetName} {
+		assets[name] = &ReleaseAsset{Name: name, URL: fmt.Sprintf("%s/%s/%s", p.baseURL, tagName, name)}
+	}
+	return assets, nil
+}
+
+// Download downloads the asset with the token as a bearer token.
+func (p *httpReleaseProvider) Download(ctx context.Context, asset *ReleaseAsset) (io.ReadCloser, error) {
+	var value string
+	if p.token != "" {
+		value = "Bearer " + p.token
+	}
+	return getURL(ctx, asset.URL, p.host, "Authorization", value)
+}
@@ -0,0 +1,263 @@
+package plugin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// testRelease is a fake release containing a plugin binary and the checksum file.
+type testRelease struct {
+	assetName string
+	assets    map[string][]byte
+}
+
+func newTestRelease(t *testing.T, name string) *testRelease {
+	config := NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{Name: name})
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	f, err := w.Create("tflint-ruleset-" + name + fileExt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("plugin binary")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return &testRelease{
+		assetName: config.AssetName(),
+		assets: map[string][]byte{
+			config.AssetName(): buf.Bytes(),
+			"checksums.txt":    []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), config.AssetName())),
+		},
+	}
+}
+
+// testTokenEnv sets "secret" as the token of the test server.
+func testTokenEnv(t *testing.T, server *httptest.Server) {
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TFLINT_TOKEN_"+strings.NewReplacer(".", "_", ":", "_").Replace(u.Host), "secret")
+}
+
+func Test_Install_releaseProviders(t *testing.T) {
+	release := newTestRelease(t, "foo")
+
+	tests := []struct {
+		name       string
+		sourceType string
+		path       string
+		handler    func(t *testing.T, server **httptest.Server) http.HandlerFunc
+	}{
+		{
+			name: "GitHub Enterprise Server",
+			path: "org/tflint-ruleset-foo",
+			handler: func(t *testing.T, server **httptest.Server) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Authorization") != "Bearer secret" {
+						t.Errorf("unexpected authorization header: %s", r.Header.Get("Authorization"))
+					}
+
+					switch r.URL.Path {
+					case "/api/v3/repos/org/tflint-ruleset-foo/releases/tags/v0.1.0":
+						fmt.Fprintf(w, `{"assets": [{"id": 1, "name": "checksums.txt"}, {"id": 2, "name": %q}]}`, release.assetName)
+					case "/api/v3/repos/org/tflint-ruleset-foo/releases/assets/1":
+						w.Write(release.assets["checksums.txt"])
+					case "/api/v3/repos/org/tflint-ruleset-foo/releases/assets/2":
+						w.Write(release.assets[release.assetName])
+					default:
+						http.NotFound(w, r)
+					}
+				}
+			},
+		},
+		{
+			name:       "GitLab",
+			sourceType: "gitlab",
+			path:       "group/subgroup/tflint-ruleset-foo",
+			handler: func(t *testing.T, server **httptest.Server) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+						t.Errorf("unexpected token: %s", r.Header.Get("PRIVATE-TOKEN"))
+					}
+
+					switch r.URL.EscapedPath() {
+					case "/api/v4/projects/group%2Fsubgroup%2Ftflint-ruleset-foo/releases/v0.1.0":
+						links := []map[string]string{}
+						for name := range release.assets {
+							links = append(links, map[string]string{
+								"name":             name,
+								"url":              "https://example.com/not-used",
+								"direct_asset_url": (*server).URL + "/downloads/" + name,
+							})
+						}
+						json.NewEncoder(w).Encode(map[string]interface{}{"assets": map[string]interface{}{"links": links}})
+					default:
+						name := strings.TrimPrefix(r.URL.Path, "/downloads/")
+						if asset, exists := release.assets[name]; exists {
+							w.Write(asset)
+							return
+						}
+						http.NotFound(w, r)
+					}
+				}
+			},
+		},
+		{
+			name:       "HTTP",
+			sourceType: "http",
+			path:       "plugins/tflint-ruleset-foo",
+			handler: func(t *testing.T, server **httptest.Server) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Authorization") != "Bearer secret" {
+						t.Errorf("unexpected authorization header: %s", r.Header.Get("Authorization"))
+					}
+
+					name := strings.TrimPrefix(r.URL.Path, "/plugins/tflint-ruleset-foo/v0.1.0/")
+					if asset, exists := release.assets[name]; exists {
+						w.Write(asset)
+						return
+					}
+					http.NotFound(w, r)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewTLSServer(test.handler(t, &server))
+			defer server.Close()
+
+			original := httpClient
+			httpClient = server.Client()
+			defer func() { httpClient = original }()
+			originalRoot := PluginRoot
+			PluginRoot = t.TempDir()
+			defer func() { PluginRoot = originalRoot }()
+			testTokenEnv(t, server)
+
+			pluginConfig := &tflint.PluginConfig{
+				Name:       "foo",
+				Enabled:    true,
+				Version:    "0.1.0",
+				Source:     strings.TrimPrefix(server.URL, "https://") + "/" + test.path,
+				SourceType: test.sourceType,
+			}
+			parts := strings.Split(pluginConfig.Source, "/")
+			pluginConfig.SourceOwner = strings.Join(parts[1:len(parts)-1], "/")
+			pluginConfig.SourceRepo = parts[len(parts)-1]
+
+			path, err := NewInstallConfig(tflint.EmptyConfig(), pluginConfig).Install()
+			if err != nil {
+				t.Fatalf("Failed to install: %s", err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read installed binary: %s", err)
+			}
+			if string(content) != "plugin binary" {
+				t.Fatalf("Installed binary is invalid: %s", content)
+			}
+		})
+	}
+}
+
+func Test_Install_releaseNotFound(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	original := httpClient
+	httpClient = server.Client()
+	defer func() { httpClient = original }()
+	originalRoot := PluginRoot
+	PluginRoot = t.TempDir()
+	defer func() { PluginRoot = originalRoot }()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	config := NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{
+		Name:       "foo",
+		Enabled:    true,
+		Version:    "0.1.0",
+		Source:     host + "/plugins/tflint-ruleset-foo",
+		SourceType: "http",
+		SourceRepo: "tflint-ruleset-foo",
+	})
+
+	_, err := config.Install()
+	want := fmt.Sprintf("Failed to download checksums.txt: GET %s/plugins/tflint-ruleset-foo/v0.1.0/checksums.txt: 404 Not Found", server.URL)
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, but got %v", want, err)
+	}
+}
+
+func Test_getURL_credentials(t *testing.T) {
+	// Assets can be served from other hosts, such as storage services
+	storage := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "" {
+			t.Error("the token must not be sent to other hosts")
+		}
+		w.Write([]byte("asset"))
+	}))
+	defer storage.Close()
+	source := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, storage.URL+"/asset", http.StatusFound)
+	}))
+	defer source.Close()
+
+	// Both servers share the same certificate
+	original := httpClient
+	httpClient = source.Client()
+	defer func() { httpClient = original }()
+
+	host := strings.TrimPrefix(source.URL, "https://")
+	for _, rawURL := range []string{source.URL + "/redirect", storage.URL + "/asset"} {
+		body, err := getURL(context.Background(), rawURL, host, "PRIVATE-TOKEN", "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body.Close()
+	}
+}
+
+func Test_sourceToken(t *testing.T) {
+	t.Setenv("TFLINT_TOKEN_ghe_example__corp_com", "ghe")
+	t.Setenv("GITHUB_TOKEN", "github")
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "ghe.example-corp.com", want: "ghe"},
+		{host: "github.com", want: "github"},
+		{host: "gitlab.com", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.host, func(t *testing.T) {
+			if got := sourceToken(test.host); got != test.want {
+				t.Errorf("expected %q, but got %q", test.want, got)
+			}
+		})
+	}
+}
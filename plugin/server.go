@@ -27,6 +27,11 @@ func NewGRPCServer(runner *tflint.Runner, rootRunner *tflint.Runner, files map[s
 	return &GRPCServer{runner: runner, rootRunner: rootRunner, files: files, clientSDKVersion: sdkVersion}
 }
 
+// profile starts recording a span of the request from the plugin, and returns a function to end it.
+func (s *GRPCServer) profile(name string) func() {
+	return s.runner.Profiler.Start(tflint.ProfileCategoryRPC, name, s.runner.ModuleInstance)
+}
+
 // GetOriginalwd returns the original working directory.
 func (s *GRPCServer) GetOriginalwd() string {
 	return s.runner.Ctx.Meta.OriginalWorkingDir
@@ -39,6 +44,8 @@ func (s *GRPCServer) GetModulePath() []string {
 
 // GetModuleContent returns module content based on the passed schema and options.
 func (s *GRPCServer) GetModuleContent(bodyS *hclext.BodySchema, opts sdk.GetModuleContentOption) (*hclext.BodyContent, hcl.Diagnostics) {
+	defer s.profile("GetModuleContent")()
+
 	var module *terraform.Module
 	var ctx *terraform.Evaluator
 
@@ -70,11 +77,15 @@ func (s *GRPCServer) GetModuleContent(bodyS *hclext.BodySchema, opts sdk.GetModu
 
 // GetFile returns the hcl.File based on passed the file name.
 func (s *GRPCServer) GetFile(name string) (*hcl.File, error) {
+	defer s.profile("GetFile")()
+
 	return s.files[name], nil
 }
 
 // GetFiles returns all hcl.File in the module.
 func (s *GRPCServer) GetFiles(ty sdk.ModuleCtxType) map[string][]byte {
+	defer s.profile("GetFiles")()
+
 	switch ty {
 	case sdk.SelfModuleCtxType:
 		return s.runner.Sources()
@@ -89,20 +100,17 @@ func (s *GRPCServer) GetFiles(ty sdk.ModuleCtxType) map[string][]byte {
 // It returns an extracted body content and sources.
 // The reason for returning sources is to encode the expression, and there is room for improvement here.
 func (s *GRPCServer) GetRuleConfigContent(name string, bodyS *hclext.BodySchema) (*hclext.BodyContent, map[string][]byte, error) {
+	defer s.profile("GetRuleConfigContent")()
+
 	config := s.runner.RuleConfig(name)
 	if config == nil {
 		return &hclext.BodyContent{}, s.runner.ConfigSources(), nil
 	}
 
-	enabledByCLI := false
-	configBody := config.Body
 	// If you enable the rule through the CLI instead of the file, its hcl.Body will be nil.
-	if config.Body == nil {
-		enabledByCLI = true
-		configBody = hcl.EmptyBody()
-	}
+	enabledByCLI := config.Body == nil
 
-	body, diags := hclext.Content(configBody, bodyS)
+	body, diags := config.Content(bodyS)
 	if diags.HasErrors() {
 		if enabledByCLI {
 			return nil, s.runner.ConfigSources(), errors.New("This rule cannot be enabled with the `--enable-rule` option because it lacks the required configuration")
@@ -114,6 +122,8 @@ func (s *GRPCServer) GetRuleConfigContent(name string, bodyS *hclext.BodySchema)
 
 // EvaluateExpr returns the value of the passed expression.
 func (s *GRPCServer) EvaluateExpr(expr hcl.Expression, opts sdk.EvaluateExprOption) (cty.Value, error) {
+	defer s.profile("EvaluateExpr")()
+
 	var runner *tflint.Runner
 	switch opts.ModuleCtx {
 	case sdk.SelfModuleCtxType:
@@ -184,6 +194,8 @@ func (s *GRPCServer) EvaluateExpr(expr hcl.Expression, opts sdk.EvaluateExprOpti
 // that the issue found in that expression. This allows you to determine if the issue was caused
 // by a module argument in the case of module inspection.
 func (s *GRPCServer) EmitIssue(rule sdk.Rule, message string, location hcl.Range) error {
+	defer s.profile("EmitIssue")()
+
 	file := s.runner.File(location.Filename)
 	if file == nil {
 		s.runner.EmitIssue(rule, message, location)
@@ -9,7 +9,7 @@ import (
 	"golang.org/x/crypto/openpgp"
 )
 
-// SignatureChecker checks the signature of GitHub releases.
+// SignatureChecker checks the signature of plugin releases.
 // Determines whether to select a signing key or skip it based on the InstallConfig.
 type SignatureChecker struct {
 	config *InstallConfig
@@ -22,11 +22,12 @@ func NewSignatureChecker(config *InstallConfig) *SignatureChecker {
 
 // GetSigningKey returns an ASCII armored signing key.
 // If the plugin is under the terraform-linters organization, you can use the built-in key even if the signing_key is omitted.
+// Organizations with the same name on other hosts are not trusted.
 func (c *SignatureChecker) GetSigningKey() string {
 	if c.config.SigningKey != "" {
 		return c.config.SigningKey
 	}
-	if c.config.SourceOwner == "terraform-linters" {
+	if c.config.SourceHost() == "github.com" && c.config.SourceOwner == "terraform-linters" {
 		return builtinSigningKey
 	}
 	return c.config.SigningKey
@@ -29,14 +29,19 @@ func Test_GetSigningKey(t *testing.T) {
 		},
 		{
 			Name:     "bulit-in signing key",
-			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: "", SourceOwner: "terraform-linters"}),
+			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: "", Source: "github.com/terraform-linters/tflint-ruleset-aws", SourceOwner: "terraform-linters"}),
 			Expected: builtinSigningKey,
 		},
 		{
 			Name:     "bulit-in signing key and configured signing key",
-			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: testSigningKey, SourceOwner: "terraform-linters"}),
+			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: testSigningKey, Source: "github.com/terraform-linters/tflint-ruleset-aws", SourceOwner: "terraform-linters"}),
 			Expected: testSigningKey,
 		},
+		{
+			Name:     "other hosts",
+			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: "", Source: "ghe.example.com/terraform-linters/tflint-ruleset-aws", SourceOwner: "terraform-linters"}),
+			Expected: "",
+		},
 	}
 
 	for _, tc := range cases {
@@ -67,12 +72,12 @@ func Test_HasSigningKey(t *testing.T) {
 		},
 		{
 			Name:     "bulit-in signing key",
-			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: "", SourceOwner: "terraform-linters"}),
+			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: "", Source: "github.com/terraform-linters/tflint-ruleset-aws", SourceOwner: "terraform-linters"}),
 			Expected: true,
 		},
 		{
 			Name:     "bulit-in signing key and configured signing key",
-			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: testSigningKey, SourceOwner: "terraform-linters"}),
+			Config:   NewInstallConfig(tflint.EmptyConfig(), &tflint.PluginConfig{SigningKey: testSigningKey, Source: "github.com/terraform-linters/tflint-ruleset-aws", SourceOwner: "terraform-linters"}),
 			Expected: true,
 		},
 	}
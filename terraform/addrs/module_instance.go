@@ -49,6 +49,17 @@ func (m ModuleInstance) IsRoot() bool {
 	return len(m) == 0
 }
 
+// Child returns the address of a particular instance of a child module of
+// the receiver, identified by its name and key.
+func (m ModuleInstance) Child(name string, key InstanceKey) ModuleInstance {
+	ret := make(ModuleInstance, 0, len(m)+1)
+	ret = append(ret, m...)
+	return append(ret, ModuleInstanceStep{
+		Name:        name,
+		InstanceKey: key,
+	})
+}
+
 // String returns a string representation of the receiver, in the format used
 // within e.g. user-provided resource addresses.
 //
@@ -0,0 +1,27 @@
+package didyoumean
+
+import (
+	"github.com/agext/levenshtein"
+)
+
+// NameSuggestion tries to find a name from the given slice of suggested names
+// that is close to the given name and returns it if found. If no suggestion
+// is close enough, returns the empty string.
+//
+// The closest suggestion is returned. If the given string is equally similar
+// to two or more suggestions, earlier suggestions take precedence.
+//
+// This function is intended to be used with a relatively-small number of
+// suggestions. It's not optimized for hundreds or thousands of them.
+func NameSuggestion(given string, suggestions []string) string {
+	ret := ""
+	minDist := 3 // threshold determined experimentally
+	for _, suggestion := range suggestions {
+		dist := levenshtein.Distance(given, suggestion, nil)
+		if dist < minDist {
+			ret = suggestion
+			minDist = dist
+		}
+	}
+	return ret
+}
@@ -0,0 +1,27 @@
+package didyoumean
+
+import "testing"
+
+func TestNameSuggestion(t *testing.T) {
+	suggestions := []string{"aws_instance_invalid_type", "aws_instance_invalid_ami", "aws_instance_previous_type", "aws_instance_invalid_types"}
+
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{given: "aws_instance_invalid_typ", want: "aws_instance_invalid_type"},
+		{given: "aws_instance_invalid_am", want: "aws_instance_invalid_ami"},
+		{given: "aws_instance_invalid_amx", want: "aws_instance_invalid_ami"},
+		{given: "aws_instance_invalid_typess", want: "aws_instance_invalid_types"},
+		{given: "aws_s3_bucket_invalid_acl", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.given, func(t *testing.T) {
+			got := NameSuggestion(test.given, suggestions)
+			if got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package terraform
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/spf13/afero"
+)
+
+// IgnoreFilename is the name of the file that lists patterns of files and
+// directories to be excluded from inspection.
+const IgnoreFilename = ".tflintignore"
+
+// Excludes is a set of gitignore-style patterns to exclude files and
+// directories from inspection. Each pattern is relative to its base directory,
+// and paths passed to Match are relative to the original working directory.
+//
+// The syntax is the same as .gitignore:
+//
+//   - Blank lines and lines starting with "#" are ignored
+//   - A leading "!" negates the pattern
+//   - A trailing "/" matches only directories
+//   - A pattern containing "/" is anchored to the base directory,
+//     otherwise it matches a file or directory name at any level
+//   - "*", "?", "[...]" and "**" are glob wildcards
+//
+// As with Git, a file cannot be re-included if its parent directory is excluded.
+type Excludes struct {
+	patterns []*excludePattern
+}
+
+type excludePattern struct {
+	base string
+	// prefix is the path of the original working directory from the base directory
+	// when the base directory is its ancestor. Paths are joined with it before matching.
+	prefix   string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewExcludes returns an empty Excludes.
+func NewExcludes() *Excludes {
+	return &Excludes{patterns: []*excludePattern{}}
+}
+
+// Add adds the passed pattern relative to the base directory.
+// Blank patterns and comments are ignored.
+func (e *Excludes) Add(base string, pattern string) error {
+	p, err := parseExcludePattern(pattern)
+	if err != nil || p == nil {
+		return err
+	}
+	p.base = filepath.ToSlash(filepath.Clean(base))
+
+	e.patterns = append(e.patterns, p)
+	return nil
+}
+
+// AddAt adds the passed pattern relative to the base directory.
+// Unlike Add, the base directory is an absolute path, and the root is the absolute
+// path of the original working directory. The base directory may be an ancestor of
+// the root, such as the directory of a config file found in parent directories.
+// Patterns in other directories outside the root never match, so they are not added.
+func (e *Excludes) AddAt(root string, baseDir string, pattern string) error {
+	p, err := parseExcludePattern(pattern)
+	if err != nil || p == nil {
+		return err
+	}
+
+	base, err := filepath.Rel(root, baseDir)
+	if err != nil {
+		return err
+	}
+	p.base = filepath.ToSlash(base)
+	if p.base == ".." || strings.HasPrefix(p.base, "../") {
+		prefix, err := filepath.Rel(baseDir, root)
+		if err != nil {
+			return err
+		}
+		prefix = filepath.ToSlash(prefix)
+		if prefix == ".." || strings.HasPrefix(prefix, "../") {
+			return nil
+		}
+		p.base = "."
+		p.prefix = prefix
+	}
+
+	e.patterns = append(e.patterns, p)
+	return nil
+}
+
+// parseExcludePattern parses the passed pattern without a base directory.
+// It returns nil for blank patterns and comments.
+func parseExcludePattern(pattern string) (*excludePattern, error) {
+	raw := pattern
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return nil, nil
+	}
+
+	p := &excludePattern{}
+
+	if strings.HasPrefix(pattern, "!") {
+		p.negate = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		p.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.Contains(pattern, "/") {
+		p.anchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	if pattern == "" {
+		return nil, fmt.Errorf("`%s` is an invalid exclude pattern", raw)
+	}
+	if _, err := doublestar.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("`%s` is an invalid exclude pattern; %w", raw, err)
+	}
+	p.pattern = pattern
+
+	return p, nil
+}
+
+// LoadIgnoreFile reads patterns from the .tflintignore in the given directory.
+// The patterns are relative to the passed base directory, which is the path of
+// the directory from the original working directory.
+// A missing file is not an error.
+func (e *Excludes) LoadIgnoreFile(fs afero.Afero, dir string, base string) error {
+	file := filepath.Join(dir, IgnoreFilename)
+	if exists, err := fs.Exists(file); err != nil || !exists {
+		return nil
+	}
+
+	src, err := fs.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	for line := 1; scanner.Scan(); line++ {
+		if err := e.Add(base, scanner.Text()); err != nil {
+			return fmt.Errorf("%s:%d: %w", filepath.Join(base, IgnoreFilename), line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Match returns whether the passed path is excluded.
+// The path is relative to the original working directory.
+func (e *Excludes) Match(target string, isDir bool) bool {
+	if e == nil || len(e.patterns) == 0 {
+		return false
+	}
+
+	target = filepath.ToSlash(filepath.Clean(target))
+
+	// A file in an excluded directory is always excluded
+	parts := strings.Split(target, "/")
+	for i := 1; i < len(parts); i++ {
+		if e.match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return e.match(target, isDir)
+}
+
+func (e *Excludes) match(target string, isDir bool) bool {
+	excluded := false
+	for _, p := range e.patterns {
+		if p.match(target, isDir) {
+			excluded = !p.negate
+		}
+	}
+	return excluded
+}
+
+func (p *excludePattern) match(target string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	rel := target
+	if p.base != "." {
+		if !strings.HasPrefix(target, p.base+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(target, p.base+"/")
+	}
+	if p.prefix != "" {
+		rel = path.Join(p.prefix, rel)
+	}
+
+	if !p.anchored {
+		rel = path.Base(rel)
+	}
+	matched, err := doublestar.Match(p.pattern, rel)
+	if err != nil {
+		return false
+	}
+	return matched
+}
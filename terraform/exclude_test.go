@@ -0,0 +1,253 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestExcludes_Match(t *testing.T) {
+	type pattern struct {
+		base    string
+		pattern string
+	}
+
+	tests := []struct {
+		name     string
+		patterns []pattern
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{
+			name:     "no patterns",
+			patterns: []pattern{},
+			path:     "main.tf",
+			want:     false,
+		},
+		{
+			name:     "file name",
+			patterns: []pattern{{base: ".", pattern: "main.tf"}},
+			path:     filepath.Join("foo", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "wildcard",
+			patterns: []pattern{{base: ".", pattern: "*.generated.tf"}},
+			path:     filepath.Join("foo", "resource.generated.tf"),
+			want:     true,
+		},
+		{
+			name:     "wildcard not matched",
+			patterns: []pattern{{base: ".", pattern: "*.generated.tf"}},
+			path:     filepath.Join("foo", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "comment",
+			patterns: []pattern{{base: ".", pattern: "# main.tf"}},
+			path:     "main.tf",
+			want:     false,
+		},
+		{
+			name:     "anchored",
+			patterns: []pattern{{base: ".", pattern: "/main.tf"}},
+			path:     filepath.Join("foo", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "anchored with slash",
+			patterns: []pattern{{base: ".", pattern: "foo/main.tf"}},
+			path:     filepath.Join("foo", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "double star",
+			patterns: []pattern{{base: ".", pattern: "test/**/*.tf"}},
+			path:     filepath.Join("test", "fixtures", "basic", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "directory",
+			patterns: []pattern{{base: ".", pattern: "examples/"}},
+			path:     filepath.Join("modules", "examples"),
+			isDir:    true,
+			want:     true,
+		},
+		{
+			name:     "directory only pattern with file",
+			patterns: []pattern{{base: ".", pattern: "examples/"}},
+			path:     "examples",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "file in excluded directory",
+			patterns: []pattern{{base: ".", pattern: "examples/"}},
+			path:     filepath.Join("examples", "basic", "main.tf"),
+			want:     true,
+		},
+		{
+			name: "negation",
+			patterns: []pattern{
+				{base: ".", pattern: "*.tf"},
+				{base: ".", pattern: "!main.tf"},
+			},
+			path: "main.tf",
+			want: false,
+		},
+		{
+			name: "negation in excluded directory",
+			patterns: []pattern{
+				{base: ".", pattern: "examples/"},
+				{base: ".", pattern: "!main.tf"},
+			},
+			path: filepath.Join("examples", "main.tf"),
+			want: true,
+		},
+		{
+			name:     "base directory",
+			patterns: []pattern{{base: "modules", pattern: "/main.tf"}},
+			path:     filepath.Join("modules", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "outside of base directory",
+			patterns: []pattern{{base: "modules", pattern: "main.tf"}},
+			path:     "main.tf",
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			excludes := NewExcludes()
+			for _, p := range test.patterns {
+				if err := excludes.Add(p.base, p.pattern); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := excludes.Match(test.path, test.isDir)
+			if got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestExcludes_AddAt(t *testing.T) {
+	project, err := filepath.Abs("project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(project, "sub")
+
+	tests := []struct {
+		name    string
+		baseDir string
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{
+			name:    "root",
+			baseDir: root,
+			pattern: "legacy/",
+			path:    "legacy",
+			isDir:   true,
+			want:    true,
+		},
+		{
+			name:    "descendant",
+			baseDir: filepath.Join(root, "modules"),
+			pattern: "/main.tf",
+			path:    filepath.Join("modules", "main.tf"),
+			want:    true,
+		},
+		{
+			name:    "ancestor",
+			baseDir: project,
+			pattern: "sub/legacy/",
+			path:    filepath.Join("legacy", "main.tf"),
+			want:    true,
+		},
+		{
+			name:    "ancestor not matched",
+			baseDir: project,
+			pattern: "/legacy/",
+			path:    filepath.Join("legacy", "main.tf"),
+			want:    false,
+		},
+		{
+			name:    "ancestor with wildcard",
+			baseDir: project,
+			pattern: "*/gen.tf",
+			path:    "gen.tf",
+			want:    true,
+		},
+		{
+			name:    "outside of root",
+			baseDir: filepath.Join(project, "other"),
+			pattern: "*.tf",
+			path:    "main.tf",
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			excludes := NewExcludes()
+			if err := excludes.AddAt(root, test.baseDir, test.pattern); err != nil {
+				t.Fatal(err)
+			}
+
+			got := excludes.Match(test.path, test.isDir)
+			if got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestExcludes_LoadIgnoreFile(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	content := `# generated files
+*.generated.tf
+
+examples/
+!examples/main.tf
+`
+	if err := fs.WriteFile(filepath.Join("modules", IgnoreFilename), []byte(content), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	excludes := NewExcludes()
+	if err := excludes.LoadIgnoreFile(fs, "modules", "modules"); err != nil {
+		t.Fatal(err)
+	}
+	// Missing file is ignored
+	if err := excludes.LoadIgnoreFile(fs, ".", "."); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: filepath.Join("modules", "main.tf"), want: false},
+		{path: filepath.Join("modules", "main.generated.tf"), want: true},
+		{path: filepath.Join("modules", "examples", "main.tf"), want: true},
+		{path: "main.generated.tf", want: false},
+	}
+
+	for _, test := range tests {
+		got := excludes.Match(test.path, false)
+		if got != test.want {
+			t.Errorf("%s: want=%t, got=%t", test.path, test.want, got)
+		}
+	}
+}
@@ -176,6 +176,18 @@ func (l *Loader) loadValuesFile(file string) (InputValues, hcl.Diagnostics) {
 	return ret, nil
 }
 
+// LoadProviderLocks reads the dependency lock file (.terraform.lock.hcl) in the
+// given directory. If the lock file does not exist, empty locks are returned.
+func (l *Loader) LoadProviderLocks(dir string) (*ProviderLocks, hcl.Diagnostics) {
+	return l.parser.LoadProviderLocks(l.baseDir, dir)
+}
+
+// SetExcludes sets patterns to exclude files from loading.
+// The patterns are matched against paths relative to the original working directory.
+func (l *Loader) SetExcludes(excludes *Excludes) {
+	l.parser.excludes = excludes
+}
+
 func (l *Loader) LoadConfigDirFiles(dir string) (map[string]*hcl.File, hcl.Diagnostics) {
 	return l.parser.LoadConfigDirFiles(l.baseDir, dir)
 }
@@ -14,6 +14,8 @@ type Module struct {
 	Locals      map[string]*Local
 	ModuleCalls map[string]*ModuleCall
 
+	ProviderRequirements map[string]*RequiredProvider
+
 	SourceDir string
 
 	Sources map[string][]byte
@@ -30,6 +32,8 @@ func NewEmptyModule() *Module {
 		Locals:      map[string]*Local{},
 		ModuleCalls: map[string]*ModuleCall{},
 
+		ProviderRequirements: map[string]*RequiredProvider{},
+
 		SourceDir: "",
 
 		Sources: map[string][]byte{},
@@ -67,6 +71,18 @@ func (m *Module) build() hcl.Diagnostics {
 			for _, local := range locals {
 				m.Locals[local.Name] = local
 			}
+		case "terraform":
+			for _, requiredProviders := range block.Body.Blocks {
+				reqs, reqDiags := decodeRequiredProvidersBlock(requiredProviders)
+				diags = diags.Extend(reqDiags)
+				for _, req := range reqs {
+					if existing, exists := m.ProviderRequirements[req.Name]; exists {
+						mergeRequiredProvider(existing, req)
+					} else {
+						m.ProviderRequirements[req.Name] = req
+					}
+				}
+			}
 		}
 	}
 
@@ -155,5 +171,9 @@ var moduleSchema = &hclext.BodySchema{
 			Type: "locals",
 			Body: localBlockSchema,
 		},
+		{
+			Type: "terraform",
+			Body: terraformBlockSchema,
+		},
 	},
 }
@@ -10,6 +10,9 @@ type ModuleCall struct {
 	Name          string
 	SourceAddrRaw string
 
+	Count   hcl.Expression
+	ForEach hcl.Expression
+
 	DeclRange hcl.Range
 }
 
@@ -26,6 +29,13 @@ func decodeModuleBlock(block *hclext.Block) (*ModuleCall, hcl.Diagnostics) {
 		diags = diags.Extend(valDiags)
 	}
 
+	if attr, exists := block.Body.Attributes["count"]; exists {
+		mc.Count = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["for_each"]; exists {
+		mc.ForEach = attr.Expr
+	}
+
 	return mc, diags
 }
 
@@ -34,5 +44,11 @@ var moduleBlockSchema = &hclext.BodySchema{
 		{
 			Name: "source",
 		},
+		{
+			Name: "count",
+		},
+		{
+			Name: "for_each",
+		},
 	},
 }
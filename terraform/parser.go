@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -21,6 +22,8 @@ import (
 type Parser struct {
 	fs afero.Afero
 	p  *hclparse.Parser
+
+	excludes *Excludes
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -261,6 +264,10 @@ func (p *Parser) configDirFiles(baseDir, dir string) (primary, override []string
 		if ext == "" || isIgnoredFile(name) {
 			continue
 		}
+		if p.excludes.Match(filepath.Join(baseDir, dir, name), false) {
+			log.Printf("[INFO] %s is excluded", filepath.Join(baseDir, dir, name))
+			continue
+		}
 
 		baseName := name[:len(name)-len(ext)] // strip extension
 		isOverride := baseName == "override" || strings.HasSuffix(baseName, "_override")
@@ -239,11 +239,12 @@ func TestLoadConfigDir(t *testing.T) {
 
 func TestLoadConfigDirFiles(t *testing.T) {
 	tests := []struct {
-		name    string
-		files   map[string]string
-		baseDir string
-		dir     string
-		want    []string
+		name     string
+		files    map[string]string
+		baseDir  string
+		dir      string
+		excludes []string
+		want     []string
 	}{
 		{
 			name: "HCL native files",
@@ -320,6 +321,20 @@ func TestLoadConfigDirFiles(t *testing.T) {
 				filepath.Join("foo", "bar", "override.tf"),
 			},
 		},
+		{
+			name: "with excludes",
+			files: map[string]string{
+				filepath.Join("bar", "main.tf"):           "",
+				filepath.Join("bar", "main.generated.tf"): "",
+				filepath.Join("bar", "override.tf"):       "",
+			},
+			baseDir:  "foo",
+			dir:      "bar",
+			excludes: []string{"*.generated.tf", "/foo/bar/override.tf"},
+			want: []string{
+				filepath.Join("foo", "bar", "main.tf"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -331,6 +346,12 @@ func TestLoadConfigDirFiles(t *testing.T) {
 				}
 			}
 			parser := NewParser(fs)
+			parser.excludes = NewExcludes()
+			for _, pattern := range test.excludes {
+				if err := parser.excludes.Add(".", pattern); err != nil {
+					t.Fatal(err)
+				}
+			}
 
 			files, diags := parser.LoadConfigDirFiles(test.baseDir, test.dir)
 			if diags.HasErrors() {
@@ -0,0 +1,186 @@
+package terraform
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// LockFilename is the name of the dependency lock file generated by `terraform init`.
+const LockFilename = ".terraform.lock.hcl"
+
+// ProviderLocks is a fork of depsfile.Locks. This represents the provider
+// selections recorded in the dependency lock file.
+type ProviderLocks struct {
+	// Providers is a map of the locked providers. The keys are fully-qualified
+	// provider source addresses, such as "registry.terraform.io/hashicorp/aws".
+	Providers map[string]*ProviderLock
+}
+
+// ProviderLock is a fork of depsfile.ProviderLock. This represents a selection
+// of a particular provider version.
+type ProviderLock struct {
+	Addr           string
+	Version        *version.Version
+	VersionStr     string
+	Constraints    version.Constraints
+	ConstraintsStr string
+	Hashes         []string
+
+	DeclRange hcl.Range
+}
+
+// NewProviderLocks returns an empty ProviderLocks.
+func NewProviderLocks() *ProviderLocks {
+	return &ProviderLocks{Providers: map[string]*ProviderLock{}}
+}
+
+// Provider returns the lock for the passed provider source address, or nil if
+// the provider is not locked. The address is normalized before the lookup,
+// so "hashicorp/aws" is the same as "registry.terraform.io/hashicorp/aws".
+func (l *ProviderLocks) Provider(addr string) *ProviderLock {
+	if l == nil {
+		return nil
+	}
+	return l.Providers[NormalizeProviderSource(addr)]
+}
+
+var lockFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "provider",
+			LabelNames: []string{"source_addr"},
+		},
+	},
+}
+
+var providerLockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "version", Required: true},
+		{Name: "constraints"},
+		{Name: "hashes"},
+	},
+}
+
+// LoadProviderLocks reads the dependency lock file in the given directory.
+//
+// A missing lock file is not an error because it is only generated after
+// `terraform init`. In this case, empty locks are returned.
+//
+// If a baseDir is passed, the lock file is assumed to be loaded from that
+// directory.
+func (p *Parser) LoadProviderLocks(baseDir, dir string) (*ProviderLocks, hcl.Diagnostics) {
+	locks := NewProviderLocks()
+
+	path := filepath.Join(dir, LockFilename)
+	if exists, err := p.fs.Exists(path); err != nil || !exists {
+		return locks, nil
+	}
+
+	f, diags := p.loadHCLFile(baseDir, path)
+	if diags.HasErrors() {
+		return locks, diags
+	}
+
+	content, _, contentDiags := f.Body.PartialContent(lockFileSchema)
+	diags = diags.Extend(contentDiags)
+
+	for _, block := range content.Blocks {
+		lock, lockDiags := decodeProviderLockBlock(block)
+		diags = diags.Extend(lockDiags)
+		if lockDiags.HasErrors() {
+			continue
+		}
+
+		if _, exists := locks.Providers[lock.Addr]; exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate provider lock",
+				Detail:   fmt.Sprintf("This lock file already declared a lock for provider %s.", lock.Addr),
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+		locks.Providers[lock.Addr] = lock
+	}
+
+	return locks, diags
+}
+
+func decodeProviderLockBlock(block *hcl.Block) (*ProviderLock, hcl.Diagnostics) {
+	lock := &ProviderLock{
+		Addr:      NormalizeProviderSource(block.Labels[0]),
+		DeclRange: block.DefRange,
+	}
+
+	content, _, diags := block.Body.PartialContent(providerLockSchema)
+	if diags.HasErrors() {
+		return lock, diags
+	}
+
+	attr := content.Attributes["version"]
+	if valDiags := gohcl.DecodeExpression(attr.Expr, nil, &lock.VersionStr); valDiags.HasErrors() {
+		return lock, diags.Extend(valDiags)
+	}
+	v, err := version.NewVersion(lock.VersionStr)
+	if err != nil {
+		return lock, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid provider version number",
+			Detail:   fmt.Sprintf("The selected version number for provider %s is invalid: %s.", lock.Addr, err),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	}
+	lock.Version = v
+
+	if attr, exists := content.Attributes["constraints"]; exists {
+		if valDiags := gohcl.DecodeExpression(attr.Expr, nil, &lock.ConstraintsStr); valDiags.HasErrors() {
+			return lock, diags.Extend(valDiags)
+		}
+		constraints, err := version.NewConstraint(lock.ConstraintsStr)
+		if err != nil {
+			return lock, diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider version constraints",
+				Detail:   fmt.Sprintf("The recorded version constraints for provider %s are invalid: %s.", lock.Addr, err),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+		lock.Constraints = constraints
+	}
+
+	if attr, exists := content.Attributes["hashes"]; exists {
+		if valDiags := gohcl.DecodeExpression(attr.Expr, nil, &lock.Hashes); valDiags.HasErrors() {
+			return lock, diags.Extend(valDiags)
+		}
+	}
+
+	return lock, diags
+}
+
+const defaultProviderRegistryHost = "registry.terraform.io"
+const defaultProviderNamespace = "hashicorp"
+
+// NormalizeProviderSource returns the fully-qualified form of the given provider
+// source address. The hostname and namespace can be omitted in the same way as
+// in `required_providers`.
+//
+//	aws                                  => registry.terraform.io/hashicorp/aws
+//	hashicorp/aws                        => registry.terraform.io/hashicorp/aws
+//	registry.terraform.io/hashicorp/aws  => registry.terraform.io/hashicorp/aws
+func NormalizeProviderSource(source string) string {
+	parts := strings.Split(strings.ToLower(source), "/")
+
+	switch len(parts) {
+	case 1:
+		return strings.Join([]string{defaultProviderRegistryHost, defaultProviderNamespace, parts[0]}, "/")
+	case 2:
+		return strings.Join([]string{defaultProviderRegistryHost, parts[0], parts[1]}, "/")
+	default:
+		return strings.Join(parts, "/")
+	}
+}
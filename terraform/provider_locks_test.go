@@ -0,0 +1,197 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func TestLoadProviderLocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		baseDir string
+		dir     string
+		want    *ProviderLocks
+		errors  bool
+	}{
+		{
+			name: "lock file",
+			files: map[string]string{
+				".terraform.lock.hcl": `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "4.67.0"
+  constraints = "~> 4.0"
+  hashes = [
+    "h1:5Zfo3GfRSWBaXs4TGQNOflr1XaYj6pRnVJLX5VAjFX4=",
+    "zh:0843017ecc24385f2b45f2c5fce79dc25b258e50d516877b3affee3bef34f060",
+  ]
+}
+
+provider "registry.terraform.io/integrations/github" {
+  version = "5.25.0"
+}`,
+			},
+			baseDir: ".",
+			dir:     ".",
+			want: &ProviderLocks{
+				Providers: map[string]*ProviderLock{
+					"registry.terraform.io/hashicorp/aws": {
+						Addr:           "registry.terraform.io/hashicorp/aws",
+						Version:        version.Must(version.NewVersion("4.67.0")),
+						VersionStr:     "4.67.0",
+						Constraints:    version.MustConstraints(version.NewConstraint("~> 4.0")),
+						ConstraintsStr: "~> 4.0",
+						Hashes: []string{
+							"h1:5Zfo3GfRSWBaXs4TGQNOflr1XaYj6pRnVJLX5VAjFX4=",
+							"zh:0843017ecc24385f2b45f2c5fce79dc25b258e50d516877b3affee3bef34f060",
+						},
+						DeclRange: hcl.Range{
+							Filename: ".terraform.lock.hcl",
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 2, Column: 47},
+						},
+					},
+					"registry.terraform.io/integrations/github": {
+						Addr:       "registry.terraform.io/integrations/github",
+						Version:    version.Must(version.NewVersion("5.25.0")),
+						VersionStr: "5.25.0",
+						DeclRange: hcl.Range{
+							Filename: ".terraform.lock.hcl",
+							Start:    hcl.Pos{Line: 11, Column: 1},
+							End:      hcl.Pos{Line: 11, Column: 53},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with base dir and dir",
+			files: map[string]string{
+				filepath.Join("bar", ".terraform.lock.hcl"): `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "4.67.0"
+}`,
+			},
+			baseDir: "foo",
+			dir:     "bar",
+			want: &ProviderLocks{
+				Providers: map[string]*ProviderLock{
+					"registry.terraform.io/hashicorp/aws": {
+						Addr:       "registry.terraform.io/hashicorp/aws",
+						Version:    version.Must(version.NewVersion("4.67.0")),
+						VersionStr: "4.67.0",
+						DeclRange: hcl.Range{
+							Filename: filepath.Join("foo", "bar", ".terraform.lock.hcl"),
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 2, Column: 47},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "no lock file",
+			files:   map[string]string{},
+			baseDir: ".",
+			dir:     ".",
+			want:    NewProviderLocks(),
+		},
+		{
+			name: "invalid version",
+			files: map[string]string{
+				".terraform.lock.hcl": `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "invalid"
+}`,
+			},
+			baseDir: ".",
+			dir:     ".",
+			want:    NewProviderLocks(),
+			errors:  true,
+		},
+		{
+			name: "duplicate locks",
+			files: map[string]string{
+				".terraform.lock.hcl": `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "4.67.0"
+}
+
+provider "hashicorp/aws" {
+  version = "4.66.0"
+}`,
+			},
+			baseDir: ".",
+			dir:     ".",
+			want: &ProviderLocks{
+				Providers: map[string]*ProviderLock{
+					"registry.terraform.io/hashicorp/aws": {
+						Addr:       "registry.terraform.io/hashicorp/aws",
+						Version:    version.Must(version.NewVersion("4.67.0")),
+						VersionStr: "4.67.0",
+						DeclRange: hcl.Range{
+							Filename: ".terraform.lock.hcl",
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 2, Column: 47},
+						},
+					},
+				},
+			},
+			errors: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			for name, content := range test.files {
+				if err := fs.WriteFile(name, []byte(content), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			parser := NewParser(fs)
+
+			got, diags := parser.LoadProviderLocks(test.baseDir, test.dir)
+			if diags.HasErrors() != test.errors {
+				t.Fatalf("unexpected diagnostics: %s", diags)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+				cmp.Comparer(func(x, y *version.Version) bool { return x.Equal(y) }),
+				cmp.Comparer(func(x, y version.Constraints) bool { return x.String() == y.String() }),
+			}
+			if diff := cmp.Diff(test.want, got, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeProviderSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: "aws", want: "registry.terraform.io/hashicorp/aws"},
+		{source: "integrations/github", want: "registry.terraform.io/integrations/github"},
+		{source: "registry.terraform.io/hashicorp/aws", want: "registry.terraform.io/hashicorp/aws"},
+		{source: "example.com/Foo/Bar", want: "example.com/foo/bar"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			got := NormalizeProviderSource(test.source)
+			if got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
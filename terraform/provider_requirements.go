@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+// RequiredProvider is a fork of configs.RequiredProvider. This represents
+// an entry in the `required_providers` block.
+type RequiredProvider struct {
+	Name string
+	// Source is the fully-qualified provider source address.
+	Source string
+	// Requirement is the version constraints for the provider.
+	// This is nil if the version is not constrained.
+	Requirement    version.Constraints
+	RequirementStr string
+	// RequirementRange is the range of the version constraint expression.
+	// If the constraints are declared in multiple blocks, this is the range of the first one.
+	RequirementRange hcl.Range
+
+	DeclRange hcl.Range
+}
+
+func decodeRequiredProvidersBlock(block *hclext.Block) ([]*RequiredProvider, hcl.Diagnostics) {
+	ret := []*RequiredProvider{}
+	diags := hcl.Diagnostics{}
+
+	for name, attr := range block.Body.Attributes {
+		rp := &RequiredProvider{
+			Name:      name,
+			Source:    NormalizeProviderSource(name),
+			DeclRange: attr.Expr.Range(),
+		}
+
+		var versionExpr hcl.Expression
+		// Legacy style: `aws = "~> 4.0"`
+		// The object style can contain references like `configuration_aliases`,
+		// so decode each item instead of evaluating the whole expression.
+		if pairs, pairDiags := hcl.ExprMap(attr.Expr); !pairDiags.HasErrors() {
+			for _, pair := range pairs {
+				key := hcl.ExprAsKeyword(pair.Key)
+				if key == "" {
+					if keyDiags := gohcl.DecodeExpression(pair.Key, nil, &key); keyDiags.HasErrors() {
+						continue
+					}
+				}
+
+				switch key {
+				case "source":
+					var source string
+					valDiags := gohcl.DecodeExpression(pair.Value, nil, &source)
+					diags = diags.Extend(valDiags)
+					if !valDiags.HasErrors() {
+						rp.Source = NormalizeProviderSource(source)
+					}
+				case "version":
+					versionExpr = pair.Value
+				}
+			}
+		} else {
+			versionExpr = attr.Expr
+		}
+
+		if versionExpr != nil {
+			rp.RequirementRange = versionExpr.Range()
+			valDiags := gohcl.DecodeExpression(versionExpr, nil, &rp.RequirementStr)
+			diags = diags.Extend(valDiags)
+			if !valDiags.HasErrors() {
+				constraints, err := version.NewConstraint(rp.RequirementStr)
+				if err != nil {
+					diags = diags.Append(&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid version constraint",
+						Detail:   fmt.Sprintf("The version constraint for provider %s is invalid: %s.", name, err),
+						Subject:  versionExpr.Range().Ptr(),
+					})
+				} else {
+					rp.Requirement = constraints
+				}
+			}
+		}
+
+		ret = append(ret, rp)
+	}
+
+	return ret, diags
+}
+
+// mergeRequiredProvider merges the passed requirement into the existing one.
+// Terraform allows the same provider to be declared in multiple `terraform` blocks,
+// and the version constraints are combined in this case.
+func mergeRequiredProvider(existing, other *RequiredProvider) {
+	existing.Requirement = append(existing.Requirement, other.Requirement...)
+
+	strs := []string{}
+	for _, str := range []string{existing.RequirementStr, other.RequirementStr} {
+		if str != "" {
+			strs = append(strs, str)
+		}
+	}
+	existing.RequirementStr = strings.Join(strs, ", ")
+}
+
+var terraformBlockSchema = &hclext.BodySchema{
+	Blocks: []hclext.BlockSchema{
+		{
+			Type: "required_providers",
+			Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+		},
+	},
+}
@@ -0,0 +1,185 @@
+package terraform
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func TestProviderRequirements(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  map[string]*RequiredProvider
+	}{
+		{
+			name: "object style",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      version               = "~> 4.0"
+      configuration_aliases = [aws.alternate]
+    }
+    github = {
+      source = "integrations/github"
+    }
+  }
+}`,
+			},
+			want: map[string]*RequiredProvider{
+				"aws": {
+					Name:           "aws",
+					Source:         "registry.terraform.io/hashicorp/aws",
+					Requirement:    version.MustConstraints(version.NewConstraint("~> 4.0")),
+					RequirementStr: "~> 4.0",
+					RequirementRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 31},
+						End:      hcl.Pos{Line: 6, Column: 39},
+					},
+					DeclRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 11},
+						End:      hcl.Pos{Line: 8, Column: 6},
+					},
+				},
+				"github": {
+					Name:   "github",
+					Source: "registry.terraform.io/integrations/github",
+					DeclRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 14},
+						End:      hcl.Pos{Line: 11, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			name: "legacy style",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  required_providers {
+    aws = "~> 4.0"
+  }
+}`,
+			},
+			want: map[string]*RequiredProvider{
+				"aws": {
+					Name:           "aws",
+					Source:         "registry.terraform.io/hashicorp/aws",
+					Requirement:    version.MustConstraints(version.NewConstraint("~> 4.0")),
+					RequirementStr: "~> 4.0",
+					RequirementRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 11},
+						End:      hcl.Pos{Line: 4, Column: 19},
+					},
+					DeclRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 11},
+						End:      hcl.Pos{Line: 4, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple terraform blocks",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 4.0"
+    }
+  }
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "< 5.0"
+    }
+  }
+}`,
+			},
+			want: map[string]*RequiredProvider{
+				"aws": {
+					Name:           "aws",
+					Source:         "registry.terraform.io/hashicorp/aws",
+					Requirement:    append(version.MustConstraints(version.NewConstraint(">= 4.0")), version.MustConstraints(version.NewConstraint("< 5.0"))...),
+					RequirementStr: ">= 4.0, < 5.0",
+					RequirementRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+					DeclRange: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 11},
+						End:      hcl.Pos{Line: 7, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			name: "HCL JSON",
+			files: map[string]string{
+				"main.tf.json": `{"terraform": {"required_providers": {"aws": {"source": "hashicorp/aws", "version": "~> 4.0"}}}}`,
+			},
+			want: map[string]*RequiredProvider{
+				"aws": {
+					Name:           "aws",
+					Source:         "registry.terraform.io/hashicorp/aws",
+					Requirement:    version.MustConstraints(version.NewConstraint("~> 4.0")),
+					RequirementStr: "~> 4.0",
+					RequirementRange: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 1, Column: 85},
+						End:      hcl.Pos{Line: 1, Column: 93},
+					},
+					DeclRange: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 1, Column: 46},
+						End:      hcl.Pos{Line: 1, Column: 94},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			for name, content := range test.files {
+				if err := fs.WriteFile(name, []byte(content), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			parser := NewParser(fs)
+
+			mod, diags := parser.LoadConfigDir(".", ".")
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+				cmp.Comparer(func(x, y version.Constraints) bool { return x.String() == y.String() }),
+			}
+			if diff := cmp.Diff(test.want, mod.ProviderRequirements, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
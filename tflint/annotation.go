@@ -3,26 +3,72 @@ package tflint
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"golang.org/x/exp/slices"
 )
 
-var annotationPattern = regexp.MustCompile(`tflint-ignore: (\S+)`)
+// Annotations are written like `tflint-ignore: rule_a, rule_b -- reason="foo" until=2006-01-02`.
+// The first group is the rule names, and the second group is the options after "--".
+var annotationPattern = regexp.MustCompile(`tflint-ignore: ([^\s,]+(?:\s*,\s*[^\s,]+)*)(?:\s+--\s+(.*))?`)
+var fileAnnotationPattern = regexp.MustCompile(`tflint-ignore-file: ([^\s,]+(?:\s*,\s*[^\s,]+)*)(?:\s+--\s+(.*))?`)
+var annotationOptionPattern = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*"|[^\s"]+)`)
+
+// annotationDateLayout is the layout of the `until` option
+const annotationDateLayout = "2006-01-02"
+
+// AnnotationScope represents the range of issues affected by an annotation
+type AnnotationScope int32
+
+const (
+	// LineScope annotations affect the same line or the line right below
+	LineScope AnnotationScope = iota
+	// BlockScope annotations affect the whole range of the block right below
+	BlockScope
+	// FileScope annotations affect the whole file
+	FileScope
+)
 
 // Annotation represents comments with special meaning in TFLint
 type Annotation struct {
 	Content string
 	Token   hclsyntax.Token
+	Scope   AnnotationScope
+	// Range is the range of the block affected by the annotation.
+	// This is set only for BlockScope annotations.
+	Range hcl.Range
+	// Reason is the justification for ignoring the rules.
+	Reason string
+	// Until is the date on which the annotation expires.
+	// The zero value means that the annotation never expires.
+	Until time.Time
+	// InvalidOptions is the problems found in the options, such as unknown keys.
+	// They don't prevent the annotation from suppressing issues, and are reported by Runner.CheckAnnotations.
+	InvalidOptions []string
 }
 
 // Annotations is slice of Annotation
 type Annotations []Annotation
 
 // NewAnnotations find annotations from the passed tokens and return that list.
+//
+// An annotation placed above a block (or on the same line as the block header)
+// affects the whole block. The block ranges are determined from the syntax tree
+// of the passed file.
+//
+// `tflint-ignore-file` annotations affect the whole file. They must be written at
+// the top of the file, before any blocks and attributes.
+//
+// Files in JSON syntax (.tf.json) are also supported. See newJSONAnnotations for details.
 func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics) {
+	if strings.HasSuffix(path, ".json") {
+		return newJSONAnnotations(path, file)
+	}
+
 	ret := Annotations{}
 
 	tokens, diags := hclsyntax.LexConfig(file.Bytes, path, hcl.Pos{Byte: 0, Line: 1, Column: 1})
@@ -30,46 +76,199 @@ func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 		return ret, diags
 	}
 
+	blocks := map[int]hcl.Range{}
+	if body, ok := file.Body.(*hclsyntax.Body); ok {
+		walkBlocks(body, blocks)
+	}
+
+	configFound := false
+	// The line of the last token other than comments and newlines.
+	// It is used to determine whether a comment is trailing.
+	lastTokenLine := 0
 	for _, token := range tokens {
 		if token.Type != hclsyntax.TokenComment {
+			if token.Type != hclsyntax.TokenNewline && token.Type != hclsyntax.TokenEOF {
+				configFound = true
+				lastTokenLine = token.Range.End.Line
+			}
+			continue
+		}
+
+		if match := fileAnnotationPattern.FindStringSubmatch(string(token.Bytes)); len(match) == 3 {
+			if configFound {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "tflint-ignore-file annotation must be written at the top of file",
+					Detail:   fmt.Sprintf("tflint-ignore-file annotation is written at line %d, but it must be written before any blocks and attributes.", token.Range.Start.Line),
+					Subject:  token.Range.Ptr(),
+				})
+				continue
+			}
+			annotation := Annotation{
+				Content: match[1],
+				Token:   token,
+				Scope:   FileScope,
+			}
+			diags = diags.Extend(annotation.decodeOptions(match[2]))
+			ret = append(ret, annotation)
 			continue
 		}
 
 		match := annotationPattern.FindStringSubmatch(string(token.Bytes))
-		if len(match) != 2 {
+		if len(match) != 3 {
 			continue
 		}
-		ret = append(ret, Annotation{
+		annotation := Annotation{
 			Content: match[1],
 			Token:   token,
-		})
+		}
+		diags = diags.Extend(annotation.decodeOptions(match[2]))
+		// A trailing comment affects the block on the same line, and a comment on
+		// its own line affects the block right below.
+		blockLine := token.Range.Start.Line + 1
+		if lastTokenLine == token.Range.Start.Line {
+			blockLine = token.Range.Start.Line
+		}
+		if rng, exists := blocks[blockLine]; exists {
+			annotation.Scope = BlockScope
+			annotation.Range = rng
+		}
+		ret = append(ret, annotation)
 	}
 
 	return ret, diags
 }
 
+// decodeOptions parses options like `reason="foo" until=2006-01-02` and sets them.
+// Invalid values are returned as errors. Unknown keys and text that is not an option
+// are recorded in InvalidOptions instead, so that a typo doesn't stop the inspection.
+func (a *Annotation) decodeOptions(src string) hcl.Diagnostics {
+	diags := hcl.Diagnostics{}
+
+	last := 0
+	for _, loc := range annotationOptionPattern.FindAllStringSubmatchIndex(src, -1) {
+		if text := strings.TrimSpace(src[last:loc[0]]); text != "" {
+			a.InvalidOptions = append(a.InvalidOptions, unexpectedOptionText(text))
+		}
+		last = loc[1]
+
+		key, value := src[loc[2]:loc[3]], src[loc[4]:loc[5]]
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid annotation option",
+					Detail:   fmt.Sprintf("Failed to parse the value of `%s`: %s", key, err),
+					Subject:  a.Token.Range.Ptr(),
+				})
+				continue
+			}
+			value = unquoted
+		}
+
+		switch key {
+		case "reason":
+			a.Reason = value
+		case "until":
+			until, err := time.ParseInLocation(annotationDateLayout, value, time.Local)
+			if err != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid annotation option",
+					Detail:   fmt.Sprintf("`until` must be a date in the YYYY-MM-DD format, but got `%s`", value),
+					Subject:  a.Token.Range.Ptr(),
+				})
+				continue
+			}
+			a.Until = until
+		default:
+			a.InvalidOptions = append(a.InvalidOptions, fmt.Sprintf("`%s` is not a valid option. Valid options are `reason` and `until`", key))
+		}
+	}
+	if text := strings.TrimSpace(src[last:]); text != "" {
+		a.InvalidOptions = append(a.InvalidOptions, unexpectedOptionText(text))
+	}
+
+	return diags
+}
+
+func unexpectedOptionText(text string) string {
+	return fmt.Sprintf("`%s` is not an option. Options must be written as key=value, and values containing spaces must be quoted", text)
+}
+
+// walkBlocks collects the ranges of the blocks in the passed body recursively.
+// The keys are the line numbers of the block headers. If multiple blocks start
+// on the same line, the outermost one is kept.
+func walkBlocks(body *hclsyntax.Body, blocks map[int]hcl.Range) {
+	for _, block := range body.Blocks {
+		if _, exists := blocks[block.TypeRange.Start.Line]; !exists {
+			blocks[block.TypeRange.Start.Line] = block.Range()
+		}
+		walkBlocks(block.Body, blocks)
+	}
+}
+
 // IsAffected checks if the passed issue is affected with the annotation
 func (a *Annotation) IsAffected(issue *Issue) bool {
 	if a.Token.Range.Filename != issue.Range.Filename {
 		return false
 	}
 
-	rules := strings.Split(a.Content, ",")
-	for i, rule := range rules {
-		rules[i] = strings.TrimSpace(rule)
+	rules := a.RuleNames()
+	if !slices.Contains(rules, issue.Rule.Name()) && !slices.Contains(rules, "all") {
+		return false
 	}
 
-	if slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all") {
-		if a.Token.Range.Start.Line == issue.Range.Start.Line {
+	// Annotations in JSON syntax are properties of the objects they affect, and the objects
+	// can be written in a single line, so the ranges are compared by byte offsets.
+	if strings.HasSuffix(a.Token.Range.Filename, ".json") {
+		switch a.Scope {
+		case FileScope:
 			return true
+		case BlockScope:
+			return a.Range.ContainsOffset(issue.Range.Start.Byte)
+		default:
+			return false
 		}
-		if a.Token.Range.Start.Line == issue.Range.Start.Line-1 {
+	}
+
+	switch a.Scope {
+	case FileScope:
+		return true
+	case BlockScope:
+		if a.Range.Start.Line <= issue.Range.Start.Line && issue.Range.Start.Line <= a.Range.End.Line {
 			return true
 		}
 	}
+
+	if a.Token.Range.Start.Line == issue.Range.Start.Line {
+		return true
+	}
+	if a.Token.Range.Start.Line == issue.Range.Start.Line-1 {
+		return true
+	}
 	return false
 }
 
+// RuleNames returns the rule names written in the annotation
+func (a *Annotation) RuleNames() []string {
+	rules := strings.Split(a.Content, ",")
+	for i, rule := range rules {
+		rules[i] = strings.TrimSpace(rule)
+	}
+	return rules
+}
+
+// IsExpired checks if the annotation has expired at the passed time.
+// The annotation is effective until the end of the day specified by `until`.
+func (a *Annotation) IsExpired(now time.Time) bool {
+	if a.Until.IsZero() {
+		return false
+	}
+	return !now.Before(a.Until.AddDate(0, 0, 1))
+}
+
 // String returns the string representation of the annotation
 func (a *Annotation) String() string {
 	return fmt.Sprintf("annotation:%s (%s)", a.Content, a.Token.Range.String())
@@ -0,0 +1,213 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// jsonAnnotationKey is the property name used for comments in JSON syntax.
+// Terraform ignores properties with this name.
+const jsonAnnotationKey = "//"
+
+// newJSONAnnotations finds annotations from the passed file written in JSON syntax.
+//
+// Since JSON has no comments, annotations are written as the value of a "//" property.
+// The annotation affects the object containing the property:
+//
+//	"resource": {
+//	  "aws_instance": {
+//	    "foo": {
+//	      "//": "tflint-ignore: aws_instance_invalid_type",
+//	      "instance_type": "t1.2xlarge"
+//	    }
+//	  }
+//	}
+//
+// `tflint-ignore-file` annotations must be written in the root object.
+func newJSONAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics) {
+	walker := &jsonAnnotationWalker{
+		path:    path,
+		src:     file.Bytes,
+		decoder: json.NewDecoder(bytes.NewReader(file.Bytes)),
+		ret:     Annotations{},
+		diags:   hcl.Diagnostics{},
+	}
+
+	token, err := walker.decoder.Token()
+	if err != nil {
+		return walker.ret, walker.error(err)
+	}
+	if token != json.Delim('{') {
+		// The root must be an object. The HCL JSON parser reports this.
+		return walker.ret, walker.diags
+	}
+	if err := walker.walkObject(true); err != nil {
+		return walker.ret, walker.error(err)
+	}
+
+	return walker.ret, walker.diags
+}
+
+// jsonAnnotationWalker walks JSON tokens and collects annotations.
+// The HCL JSON parser does not expose its syntax tree, so the source is
+// decoded again to know the byte offsets of objects and "//" properties.
+type jsonAnnotationWalker struct {
+	path    string
+	src     []byte
+	decoder *json.Decoder
+	ret     Annotations
+	diags   hcl.Diagnostics
+}
+
+// walkObject walks the object whose opening brace has just been read.
+func (w *jsonAnnotationWalker) walkObject(root bool) error {
+	start := w.decoder.InputOffset() - 1
+	annotations := Annotations{}
+
+	for w.decoder.More() {
+		key, err := w.decoder.Token()
+		if err != nil {
+			return err
+		}
+		keyEnd := w.decoder.InputOffset()
+
+		value, err := w.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch v := value.(type) {
+		case json.Delim:
+			if err := w.walkNested(v); err != nil {
+				return err
+			}
+		case string:
+			if key != jsonAnnotationKey {
+				continue
+			}
+			annotation, ok := w.newAnnotation(v, keyEnd, w.decoder.InputOffset(), root)
+			if ok {
+				annotations = append(annotations, annotation)
+			}
+		}
+	}
+
+	// Read the closing brace
+	if _, err := w.decoder.Token(); err != nil {
+		return err
+	}
+	rng := w.rangeOf(start, w.decoder.InputOffset())
+
+	for _, annotation := range annotations {
+		if annotation.Scope != FileScope {
+			annotation.Scope = BlockScope
+			annotation.Range = rng
+		}
+		w.ret = append(w.ret, annotation)
+	}
+	return nil
+}
+
+// walkArray walks the array whose opening bracket has just been read.
+func (w *jsonAnnotationWalker) walkArray() error {
+	for w.decoder.More() {
+		value, err := w.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := value.(json.Delim); ok {
+			if err := w.walkNested(delim); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Read the closing bracket
+	_, err := w.decoder.Token()
+	return err
+}
+
+func (w *jsonAnnotationWalker) walkNested(delim json.Delim) error {
+	switch delim {
+	case '{':
+		return w.walkObject(false)
+	case '[':
+		return w.walkArray()
+	default:
+		panic(fmt.Sprintf("unexpected delimiter: %s", delim))
+	}
+}
+
+// newAnnotation builds an annotation from the value of a "//" property.
+// The value is located between the end of the key and the passed end offset.
+func (w *jsonAnnotationWalker) newAnnotation(value string, keyEnd int64, end int64, root bool) (Annotation, bool) {
+	start := keyEnd + int64(bytes.IndexByte(w.src[keyEnd:end], '"'))
+	token := hclsyntax.Token{
+		Type:  hclsyntax.TokenQuotedLit,
+		Bytes: w.src[start:end],
+		Range: w.rangeOf(start, end),
+	}
+
+	if match := fileAnnotationPattern.FindStringSubmatch(value); len(match) == 3 {
+		if !root {
+			w.diags = w.diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "tflint-ignore-file annotation must be written at the top of file",
+				Detail:   fmt.Sprintf("tflint-ignore-file annotation is written at line %d, but it must be written in the root object in JSON syntax.", token.Range.Start.Line),
+				Subject:  token.Range.Ptr(),
+			})
+			return Annotation{}, false
+		}
+		annotation := Annotation{
+			Content: match[1],
+			Token:   token,
+			Scope:   FileScope,
+		}
+		w.diags = w.diags.Extend(annotation.decodeOptions(match[2]))
+		return annotation, true
+	}
+
+	match := annotationPattern.FindStringSubmatch(value)
+	if len(match) != 3 {
+		return Annotation{}, false
+	}
+	annotation := Annotation{
+		Content: match[1],
+		Token:   token,
+	}
+	w.diags = w.diags.Extend(annotation.decodeOptions(match[2]))
+	return annotation, true
+}
+
+// rangeOf returns the range between the passed byte offsets.
+func (w *jsonAnnotationWalker) rangeOf(start int64, end int64) hcl.Range {
+	return hcl.Range{
+		Filename: w.path,
+		Start:    posOf(w.src, int(start)),
+		End:      posOf(w.src, int(end)),
+	}
+}
+
+// posOf returns the position of the passed byte offset in the source.
+// Columns are counted in characters like the HCL parsers.
+func posOf(src []byte, offset int) hcl.Pos {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+	return hcl.Pos{
+		Line:   bytes.Count(src[:offset], []byte{'\n'}) + 1,
+		Column: utf8.RuneCount(src[lineStart:offset]) + 1,
+		Byte:   offset,
+	}
+}
+
+func (w *jsonAnnotationWalker) error(err error) hcl.Diagnostics {
+	return w.diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Failed to parse annotations",
+		Detail:   fmt.Sprintf("Failed to parse %s as JSON: %s", w.path, err),
+	})
+}
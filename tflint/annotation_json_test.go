@@ -0,0 +1,209 @@
+package tflint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+)
+
+func Test_NewAnnotations_json(t *testing.T) {
+	src := `{
+  "//": "tflint-ignore-file: aws_instance_invalid_ami",
+  "resource": {
+    "aws_instance": {
+      "foo": {
+        "//": "tflint-ignore: aws_instance_invalid_type -- reason=\"generated\"",
+        "instance_type": "t1.2xlarge",
+        "ebs_block_device": [
+          {"//": "tflint-ignore: aws_instance_invalid_device_name", "device_name": "foo"}
+        ]
+      },
+      "bar": {
+        "//": "This is also comment",
+        "instance_type": "t2.micro"
+      }
+    }
+  }
+}`
+
+	file, diags := json.Parse([]byte(src), "resource.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ret, diags := NewAnnotations("resource.tf.json", file)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := Annotations{
+		{
+			Content: "aws_instance_invalid_device_name",
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenQuotedLit,
+				Bytes: []byte(`"tflint-ignore: aws_instance_invalid_device_name"`),
+				Range: hcl.Range{
+					Filename: "resource.tf.json",
+					Start:    hcl.Pos{Line: 9, Column: 18},
+					End:      hcl.Pos{Line: 9, Column: 67},
+				},
+			},
+			Scope: BlockScope,
+			Range: hcl.Range{
+				Filename: "resource.tf.json",
+				Start:    hcl.Pos{Line: 9, Column: 11},
+				End:      hcl.Pos{Line: 9, Column: 90},
+			},
+		},
+		{
+			Content: "aws_instance_invalid_type",
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenQuotedLit,
+				Bytes: []byte(`"tflint-ignore: aws_instance_invalid_type -- reason=\"generated\""`),
+				Range: hcl.Range{
+					Filename: "resource.tf.json",
+					Start:    hcl.Pos{Line: 6, Column: 15},
+					End:      hcl.Pos{Line: 6, Column: 81},
+				},
+			},
+			Scope: BlockScope,
+			Range: hcl.Range{
+				Filename: "resource.tf.json",
+				Start:    hcl.Pos{Line: 5, Column: 14},
+				End:      hcl.Pos{Line: 11, Column: 8},
+			},
+			Reason: "generated",
+		},
+		{
+			Content: "aws_instance_invalid_ami",
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenQuotedLit,
+				Bytes: []byte(`"tflint-ignore-file: aws_instance_invalid_ami"`),
+				Range: hcl.Range{
+					Filename: "resource.tf.json",
+					Start:    hcl.Pos{Line: 2, Column: 9},
+					End:      hcl.Pos{Line: 2, Column: 55},
+				},
+			},
+			Scope: FileScope,
+		},
+	}
+
+	opts := cmpopts.IgnoreFields(hcl.Pos{}, "Byte")
+	if diff := cmp.Diff(expected, ret, opts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_NewAnnotations_jsonInvalidFileAnnotation(t *testing.T) {
+	src := `{
+  "resource": {
+    "//": "tflint-ignore-file: aws_instance_invalid_type"
+  }
+}`
+
+	file, diags := json.Parse([]byte(src), "resource.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	_, diags = NewAnnotations("resource.tf.json", file)
+	if !diags.HasErrors() {
+		t.Fatal("expected error is not occurred")
+	}
+
+	expected := "resource.tf.json:3,11-58: tflint-ignore-file annotation must be written at the top of file; tflint-ignore-file annotation is written at line 3, but it must be written in the root object in JSON syntax."
+	if diags.Error() != expected {
+		t.Fatalf("expected=%s, got=%s", expected, diags.Error())
+	}
+}
+
+func Test_IsAffected_json(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Src      string
+		Offset   string
+		Expected bool
+	}{
+		{
+			Name: "affected",
+			Src: `{
+  "resource": {
+    "aws_instance": {
+      "foo": {
+        "//": "tflint-ignore: test_rule",
+        "instance_type": "t1.2xlarge"
+      },
+      "bar": {
+        "instance_type": "t1.2xlarge"
+      }
+    }
+  }
+}`,
+			Offset:   `"t1.2xlarge"`,
+			Expected: true,
+		},
+		{
+			Name: "not affected",
+			Src: `{
+  "resource": {
+    "aws_instance": {
+      "foo": {
+        "//": "tflint-ignore: test_rule",
+        "instance_type": "t1.2xlarge"
+      },
+      "bar": {
+        "instance_type": "t2.micro"
+      }
+    }
+  }
+}`,
+			Offset:   `"t2.micro"`,
+			Expected: false,
+		},
+		{
+			Name:     "affected in a single line",
+			Src:      `{"resource":{"aws_instance":{"foo":{"//":"tflint-ignore: test_rule","instance_type":"t1.2xlarge"},"bar":{"instance_type":"t2.micro"}}}}`,
+			Offset:   `"t1.2xlarge"`,
+			Expected: true,
+		},
+		{
+			Name:     "not affected in a single line",
+			Src:      `{"resource":{"aws_instance":{"foo":{"//":"tflint-ignore: test_rule","instance_type":"t1.2xlarge"},"bar":{"instance_type":"t2.micro"}}}}`,
+			Offset:   `"t2.micro"`,
+			Expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file, diags := json.Parse([]byte(test.Src), "resource.tf.json")
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			annotations, diags := NewAnnotations("resource.tf.json", file)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if len(annotations) != 1 {
+				t.Fatalf("expected 1 annotation, but got %d", len(annotations))
+			}
+
+			offset := strings.Index(test.Src, test.Offset)
+			issue := &Issue{
+				Rule: &testRule{},
+				Range: hcl.Range{
+					Filename: "resource.tf.json",
+					Start:    hcl.Pos{Line: strings.Count(test.Src[:offset], "\n") + 1, Byte: offset},
+				},
+			}
+			got := annotations[0].IsAffected(issue)
+			if got != test.Expected {
+				t.Fatalf("want=%t, got=%t", test.Expected, got)
+			}
+		})
+	}
+}
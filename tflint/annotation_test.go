@@ -2,6 +2,7 @@ package tflint
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -73,6 +74,206 @@ resource "aws_instance" "foo" {
 	}
 }
 
+func Test_NewAnnotations_scope(t *testing.T) {
+	src := `# tflint-ignore-file: aws_instance_invalid_ami
+# This is also comment
+
+# tflint-ignore: aws_instance_invalid_type
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+
+  ebs_block_device { # tflint-ignore: aws_instance_invalid_device_name
+    device_name = "foo"
+  }
+  iam_instance_profile = "foo" # tflint-ignore: aws_instance_invalid_iam_profile
+  ebs_block_device {
+    device_name = "bar"
+  }
+}`
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), "resource.tf", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ret, diags := NewAnnotations("resource.tf", file)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := Annotations{
+		{
+			Content: "aws_instance_invalid_ami",
+			Scope:   FileScope,
+		},
+		{
+			Content: "aws_instance_invalid_type",
+			Scope:   BlockScope,
+			Range: hcl.Range{
+				Filename: "resource.tf",
+				Start:    hcl.Pos{Line: 5, Column: 1},
+				End:      hcl.Pos{Line: 15, Column: 2},
+			},
+		},
+		{
+			Content: "aws_instance_invalid_device_name",
+			Scope:   BlockScope,
+			Range: hcl.Range{
+				Filename: "resource.tf",
+				Start:    hcl.Pos{Line: 8, Column: 3},
+				End:      hcl.Pos{Line: 10, Column: 4},
+			},
+		},
+		{
+			Content: "aws_instance_invalid_iam_profile",
+			Scope:   LineScope,
+		},
+	}
+
+	opts := []cmp.Option{
+		cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+		cmpopts.IgnoreFields(Annotation{}, "Token"),
+	}
+	if diff := cmp.Diff(expected, ret, opts...); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_NewAnnotations_invalidFileAnnotation(t *testing.T) {
+	src := `resource "aws_instance" "foo" {
+  # tflint-ignore-file: aws_instance_invalid_type
+  instance_type = "t2.micro"
+}`
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), "resource.tf", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	_, diags = NewAnnotations("resource.tf", file)
+	if !diags.HasErrors() {
+		t.Fatal("expected error is not occurred")
+	}
+
+	expected := "resource.tf:2,3-3,1: tflint-ignore-file annotation must be written at the top of file; tflint-ignore-file annotation is written at line 2, but it must be written before any blocks and attributes."
+	if diags.Error() != expected {
+		t.Fatalf("expected=%s, got=%s", expected, diags.Error())
+	}
+}
+
+func Test_NewAnnotations_options(t *testing.T) {
+	src := `resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type -- reason="legacy \"t1\" instances" until=2023-01-31
+  instance_type = "t1.micro"
+  ami = "ami-12345678" # tflint-ignore: aws_instance_invalid_ami, aws_instance_previous_type -- reason=migrating
+}`
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), "resource.tf", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ret, diags := NewAnnotations("resource.tf", file)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := Annotations{
+		{
+			Content: "aws_instance_invalid_type",
+			Reason:  `legacy "t1" instances`,
+			Until:   time.Date(2023, 1, 31, 0, 0, 0, 0, time.Local),
+		},
+		{
+			Content: "aws_instance_invalid_ami, aws_instance_previous_type",
+			Reason:  "migrating",
+		},
+	}
+
+	opts := []cmp.Option{
+		cmpopts.IgnoreFields(Annotation{}, "Token"),
+	}
+	if diff := cmp.Diff(expected, ret, opts...); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_NewAnnotations_unknownOptions(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Src      string
+		Reason   string
+		Expected []string
+	}{
+		{
+			Name:     "unknown key",
+			Src:      `# tflint-ignore: aws_instance_invalid_type -- reson=legacy`,
+			Expected: []string{"`reson` is not a valid option. Valid options are `reason` and `until`"},
+		},
+		{
+			Name:     "unquoted multi-word reason",
+			Src:      `# tflint-ignore: aws_instance_invalid_type -- reason=legacy AMI`,
+			Reason:   "legacy",
+			Expected: []string{"`AMI` is not an option. Options must be written as key=value, and values containing spaces must be quoted"},
+		},
+		{
+			Name:     "text before options",
+			Src:      `# tflint-ignore: aws_instance_invalid_type -- legacy AMI reason=legacy`,
+			Reason:   "legacy",
+			Expected: []string{"`legacy AMI` is not an option. Options must be written as key=value, and values containing spaces must be quoted"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(test.Src), "resource.tf", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			ret, diags := NewAnnotations("resource.tf", file)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if len(ret) != 1 {
+				t.Fatalf("expected 1 annotation, but got %d", len(ret))
+			}
+			if ret[0].Reason != test.Reason {
+				t.Errorf("reason: want=%q, got=%q", test.Reason, ret[0].Reason)
+			}
+			if diff := cmp.Diff(test.Expected, ret[0].InvalidOptions); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_NewAnnotations_invalidOptions(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Src      string
+		Expected string
+	}{
+		{
+			Name:     "invalid date",
+			Src:      `# tflint-ignore: aws_instance_invalid_type -- until=2023/01/31`,
+			Expected: "resource.tf:1,1-63: Invalid annotation option; `until` must be a date in the YYYY-MM-DD format, but got `2023/01/31`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(test.Src), "resource.tf", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			_, diags = NewAnnotations("resource.tf", file)
+			if !diags.HasErrors() {
+				t.Fatal("expected error is not occurred")
+			}
+			if diags.Error() != test.Expected {
+				t.Fatalf("expected=%s, got=%s", test.Expected, diags.Error())
+			}
+		})
+	}
+}
+
 func Test_IsAffected(t *testing.T) {
 	issue := &Issue{
 		Rule:    &testRule{},
@@ -200,6 +401,76 @@ func Test_IsAffected(t *testing.T) {
 			},
 			Expected: true,
 		},
+		{
+			Name: "affected (block)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 0},
+					},
+				},
+				Scope: BlockScope,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1},
+					End:      hcl.Pos{Line: 3},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "not affected (outside of block)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3},
+					},
+				},
+				Scope: BlockScope,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 4},
+					End:      hcl.Pos{Line: 6},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "affected (file)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 10},
+					},
+				},
+				Scope: FileScope,
+			},
+			Expected: true,
+		},
+		{
+			Name: "not affected (file, another rule)",
+			Annotation: Annotation{
+				Content: "test_another_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 10},
+					},
+				},
+				Scope: FileScope,
+			},
+			Expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -211,3 +482,48 @@ func Test_IsAffected(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsExpired(t *testing.T) {
+	until := time.Date(2023, 1, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		Name       string
+		Annotation Annotation
+		Now        time.Time
+		Expected   bool
+	}{
+		{
+			Name:       "no expiry",
+			Annotation: Annotation{},
+			Now:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+			Expected:   false,
+		},
+		{
+			Name:       "before the date",
+			Annotation: Annotation{Until: until},
+			Now:        time.Date(2023, 1, 30, 12, 0, 0, 0, time.Local),
+			Expected:   false,
+		},
+		{
+			Name:       "on the date",
+			Annotation: Annotation{Until: until},
+			Now:        time.Date(2023, 1, 31, 23, 59, 59, 0, time.Local),
+			Expected:   false,
+		},
+		{
+			Name:       "after the date",
+			Annotation: Annotation{Until: until},
+			Now:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+			Expected:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := test.Annotation.IsExpired(test.Now)
+			if got != test.Expected {
+				t.Fatalf("want=%t, got=%t", test.Expected, got)
+			}
+		})
+	}
+}
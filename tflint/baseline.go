@@ -0,0 +1,159 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+// baselineVersion is the version of the baseline file format
+const baselineVersion = 1
+
+// Baseline is a set of issues recorded at some point.
+// Issues found in the baseline are hidden so that only new issues are reported.
+type Baseline struct {
+	Version int              `json:"version"`
+	Issues  []*BaselineIssue `json:"issues"`
+}
+
+// BaselineIssue is an issue recorded in the baseline.
+// Issues are matched by fingerprints. Other fields are for humans reading the file.
+type BaselineIssue struct {
+	Rule        string `json:"rule"`
+	Filename    string `json:"filename"`
+	Message     string `json:"message"`
+	Fingerprint string `json:"fingerprint"`
+
+	// rng is the location of the entry in the baseline file
+	rng hcl.Range
+}
+
+// NewBaseline returns a baseline recording the passed issues.
+// Suppressed issues are not recorded. Fingerprints must be set on the issues.
+func NewBaseline(issues Issues) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, Issues: []*BaselineIssue{}}
+
+	for _, issue := range issues.Unsuppressed().Sort() {
+		baseline.Issues = append(baseline.Issues, &BaselineIssue{
+			Rule:        issue.Rule.Name(),
+			Filename:    filepath.ToSlash(issue.Range.Filename),
+			Message:     issue.Message,
+			Fingerprint: issue.Fingerprint,
+		})
+	}
+	return baseline
+}
+
+// LoadBaseline loads the baseline file
+func LoadBaseline(fs afero.Afero, path string) (*Baseline, error) {
+	log.Printf("[INFO] Load baseline: %s", path)
+
+	src, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline *Baseline
+	if err := json.Unmarshal(src, &baseline); err != nil {
+		return nil, fmt.Errorf("%s is not a valid baseline file; %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("%s has an unsupported version %d. Supported version is %d", path, baseline.Version, baselineVersion)
+	}
+
+	// Locate each entry by its fingerprint for reporting unused entries.
+	// Entries with the same fingerprint are located in order of appearance.
+	offset := 0
+	for _, entry := range baseline.Issues {
+		needle := []byte(fmt.Sprintf("%q", entry.Fingerprint))
+		idx := bytes.Index(src[offset:], needle)
+		if idx < 0 {
+			entry.rng = hcl.Range{Filename: path}
+			continue
+		}
+		start := offset + idx
+		offset = start + len(needle)
+		entry.rng = hcl.Range{Filename: path, Start: posOf(src, start), End: posOf(src, offset)}
+	}
+
+	return baseline, nil
+}
+
+// Write writes the baseline to the passed path
+func (b *Baseline) Write(fs afero.Afero, path string) error {
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(path, append(out, '\n'), 0o644)
+}
+
+// Apply marks the passed issues found in the baseline as suppressed.
+// Each entry suppresses at most one issue.
+//
+// It returns issues for the entries that no longer match any issues so that
+// the baseline can be pruned. Entries for files not in the passed sources are
+// ignored because these files are not inspected.
+func (b *Baseline) Apply(issues Issues, config *Config, sources map[string][]byte) Issues {
+	entries := map[string][]*BaselineIssue{}
+	for _, entry := range b.Issues {
+		entries[entry.Fingerprint] = append(entries[entry.Fingerprint], entry)
+	}
+
+	for _, issue := range issues.Unsuppressed() {
+		matched := entries[issue.Fingerprint]
+		if len(matched) == 0 {
+			continue
+		}
+		log.Printf("[INFO] %s (%s) is ignored by the baseline", issue.Range.String(), issue.Rule.Name())
+		issue.Suppression = &Suppression{
+			Kind:          SuppressionExternal,
+			Justification: "Recorded in the baseline",
+			Range:         matched[0].rng,
+		}
+		entries[issue.Fingerprint] = matched[1:]
+	}
+
+	inspected := map[string]bool{}
+	for filename := range sources {
+		inspected[filepath.ToSlash(filename)] = true
+	}
+
+	ret := Issues{}
+	if !config.coreRuleEnabled(unusedBaselineEntryRule) {
+		return ret
+	}
+	for _, entry := range b.Issues {
+		unused := false
+		for _, e := range entries[entry.Fingerprint] {
+			if e == entry {
+				unused = true
+			}
+		}
+		if !unused || !inspected[entry.Filename] {
+			continue
+		}
+		// Rule configs are looked up by the file the entry refers to, as well as issues emitted by runners.
+		// Filenames in the baseline are relative to the current directory.
+		path, err := filepath.Abs(filepath.FromSlash(entry.Filename))
+		if err != nil {
+			path = entry.Filename
+		}
+		ruleConfig, overridden := config.ruleConfigFor(unusedBaselineEntryRule.Name(), path)
+		if overridden && !ruleConfig.Enabled {
+			log.Printf("[INFO] The unused baseline entry for %s is ignored because the rule is disabled by overrides", entry.Filename)
+			continue
+		}
+		ret = append(ret, &Issue{
+			Rule:    ruleConfig.applySeverity(unusedBaselineEntryRule),
+			Message: fmt.Sprintf("The baseline entry for %q in %s no longer matches any issues", entry.Rule, entry.Filename),
+			Range:   entry.rng,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,137 @@
+package tflint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_Baseline(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+
+	recorded := Issues{
+		{Rule: &testRule{}, Message: "foo", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}}, Fingerprint: "aaa"},
+		{Rule: &testRule{}, Message: "foo", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2}}, Fingerprint: "aaa"},
+		{Rule: &testRule{}, Message: "bar", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}}, Fingerprint: "bbb"},
+		{Rule: &testRule{}, Message: "baz", Range: hcl.Range{Filename: "other.tf", Start: hcl.Pos{Line: 1}}, Fingerprint: "ccc"},
+		{Rule: &testRule{}, Message: "suppressed", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4}}, Fingerprint: "ddd", Suppression: &Suppression{Kind: SuppressionInSource}},
+	}
+	if err := NewBaseline(recorded).Write(fs, ".tflint-baseline.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := LoadBaseline(fs, ".tflint-baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline.Issues) != 4 {
+		t.Fatalf("suppressed issues must not be recorded, but got %d issues", len(baseline.Issues))
+	}
+
+	issues := Issues{
+		{Rule: &testRule{}, Message: "foo", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 10}}, Fingerprint: "aaa"},
+		{Rule: &testRule{}, Message: "new", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 11}}, Fingerprint: "eee"},
+	}
+	sources := map[string][]byte{"main.tf": {}}
+
+	got := baseline.Apply(issues, EmptyConfig(), sources)
+
+	if issues[0].Suppression == nil || issues[0].Suppression.Kind != SuppressionExternal {
+		t.Errorf("the issue in the baseline must be suppressed")
+	}
+	if issues[1].Suppression != nil {
+		t.Errorf("the new issue must not be suppressed")
+	}
+
+	// The second "aaa" entry and the "bbb" entry are unused.
+	// The "ccc" entry is ignored because other.tf is not inspected.
+	want := Issues{
+		{
+			Rule:    unusedBaselineEntryRule,
+			Message: `The baseline entry for "test_rule" in main.tf no longer matches any issues`,
+			Range: hcl.Range{
+				Filename: ".tflint-baseline.json",
+				Start:    hcl.Pos{Line: 14, Column: 22, Byte: 259},
+				End:      hcl.Pos{Line: 14, Column: 27, Byte: 264},
+			},
+		},
+		{
+			Rule:    unusedBaselineEntryRule,
+			Message: `The baseline entry for "test_rule" in main.tf no longer matches any issues`,
+			Range: hcl.Range{
+				Filename: ".tflint-baseline.json",
+				Start:    hcl.Pos{Line: 20, Column: 22, Byte: 379},
+				End:      hcl.Pos{Line: 20, Column: 27, Byte: 384},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(coreRule{})); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_Baseline_ruleConfig(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+
+	recorded := Issues{
+		{Rule: &testRule{}, Message: "foo", Range: hcl.Range{Filename: "main.tf"}, Fingerprint: "aaa"},
+		{Rule: &testRule{}, Message: "bar", Range: hcl.Range{Filename: "examples/main.tf"}, Fingerprint: "bbb"},
+	}
+	if err := NewBaseline(recorded).Write(fs, ".tflint-baseline.json"); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(fs, ".tflint-baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := EmptyConfig()
+	config.Rules["tflint_unused_baseline_entry"] = &RuleConfig{Name: "tflint_unused_baseline_entry", Enabled: true, Severity: "error"}
+	config.Overrides = []*ConfigOverride{
+		{
+			Files: []string{"examples/**"},
+			Rules: map[string]*RuleConfig{
+				"tflint_unused_baseline_entry": {Name: "tflint_unused_baseline_entry", Enabled: false},
+			},
+			baseDir: base,
+		},
+	}
+	sources := map[string][]byte{"main.tf": {}, "examples/main.tf": {}}
+
+	got := baseline.Apply(Issues{}, config, sources)
+
+	// The entry for examples/main.tf is ignored because the rule is disabled by the override.
+	if len(got) != 1 {
+		t.Fatalf("want 1 issue, but got %d issues", len(got))
+	}
+	if got[0].Message != `The baseline entry for "test_rule" in main.tf no longer matches any issues` {
+		t.Errorf("unexpected message: %s", got[0].Message)
+	}
+	if got[0].Rule.Severity() != sdk.ERROR {
+		t.Errorf("want severity %s, but got %s", sdk.ERROR, got[0].Rule.Severity())
+	}
+}
+
+func Test_LoadBaseline_invalidVersion(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile(".tflint-baseline.json", []byte(`{"version": 2, "issues": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadBaseline(fs, ".tflint-baseline.json")
+	if err == nil {
+		t.Fatal("expected error is not occurred")
+	}
+	expected := ".tflint-baseline.json has an unsupported version 2. Supported version is 1"
+	if err.Error() != expected {
+		t.Fatalf("expected=%s, got=%s", expected, err)
+	}
+}
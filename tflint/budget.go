@@ -0,0 +1,104 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Budget is the maximum number of issues allowed for a group of issues,
+// such as issues of a rule or issues with the warning severity.
+type Budget struct {
+	// Name describes the group of issues, like `rule "terraform_typed_variables"` or "warnings".
+	Name  string
+	Max   int
+	Count int
+}
+
+// Exceeded returns true if the number of issues is over the budget
+func (b *Budget) Exceeded() bool {
+	return b.Count > b.Max
+}
+
+// String returns the description of the budget usage
+func (b *Budget) String() string {
+	return fmt.Sprintf("%s: %d issue(s) found, %d allowed", b.Name, b.Count, b.Max)
+}
+
+// RuleBudgets counts issues against `max_issues` of the rule config.
+// It returns budgets of rules that have `max_issues`, sorted by rule name,
+// and the rest of the issues that are not covered by these budgets.
+func RuleBudgets(issues Issues, rules map[string]*RuleConfig) ([]*Budget, Issues) {
+	budgets := map[string]*Budget{}
+	rest := Issues{}
+
+	for _, issue := range issues {
+		name := issue.Rule.Name()
+		rule, exists := rules[name]
+		if !exists || rule.MaxIssues == nil {
+			rest = append(rest, issue)
+			continue
+		}
+
+		budget, exists := budgets[name]
+		if !exists {
+			budget = &Budget{Name: fmt.Sprintf("rule %q", name), Max: *rule.MaxIssues}
+			budgets[name] = budget
+		}
+		budget.Count++
+	}
+
+	// Rules with max_issues are budgets even if no issues are found
+	for name, rule := range rules {
+		if _, exists := budgets[name]; !exists && rule.MaxIssues != nil {
+			budgets[name] = &Budget{Name: fmt.Sprintf("rule %q", name), Max: *rule.MaxIssues}
+		}
+	}
+
+	names := make([]string, 0, len(budgets))
+	for name := range budgets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := make([]*Budget, len(names))
+	for i, name := range names {
+		ret[i] = budgets[name]
+	}
+	return ret, rest
+}
+
+// SeverityBudgets counts issues against the maximum number of errors and warnings.
+// A nil maximum means no budget for the severity.
+// It returns the budgets and the rest of the issues that are not covered by these budgets.
+func SeverityBudgets(issues Issues, maxErrors *int, maxWarnings *int) ([]*Budget, Issues) {
+	var errors, warnings *Budget
+	if maxErrors != nil {
+		errors = &Budget{Name: "errors", Max: *maxErrors}
+	}
+	if maxWarnings != nil {
+		warnings = &Budget{Name: "warnings", Max: *maxWarnings}
+	}
+
+	rest := Issues{}
+	for _, issue := range issues {
+		switch {
+		case issue.Rule.Severity() == sdk.ERROR && errors != nil:
+			errors.Count++
+		case issue.Rule.Severity() == sdk.WARNING && warnings != nil:
+			warnings.Count++
+		default:
+			rest = append(rest, issue)
+		}
+	}
+
+	budgets := []*Budget{}
+	if errors != nil {
+		budgets = append(budgets, errors)
+	}
+	if warnings != nil {
+		budgets = append(budgets, warnings)
+	}
+	return budgets, rest
+}
@@ -0,0 +1,103 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+type budgetTestRule struct {
+	name     string
+	severity Severity
+}
+
+func (r *budgetTestRule) Name() string       { return r.name }
+func (r *budgetTestRule) Severity() Severity { return r.severity }
+func (r *budgetTestRule) Link() string       { return "" }
+
+func Test_RuleBudgets(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	issue := func(name string) *Issue {
+		return &Issue{Rule: &budgetTestRule{name: name, severity: sdk.ERROR}, Message: "test"}
+	}
+
+	issues := Issues{issue("rule_a"), issue("rule_b"), issue("rule_a"), issue("rule_c")}
+	rules := map[string]*RuleConfig{
+		"rule_a": {Name: "rule_a", Enabled: true, MaxIssues: intPtr(1)},
+		"rule_b": {Name: "rule_b", Enabled: true},
+		"rule_d": {Name: "rule_d", Enabled: true, MaxIssues: intPtr(0)},
+	}
+
+	budgets, rest := RuleBudgets(issues, rules)
+
+	want := []*Budget{
+		{Name: `rule "rule_a"`, Max: 1, Count: 2},
+		{Name: `rule "rule_d"`, Max: 0, Count: 0},
+	}
+	if diff := cmp.Diff(want, budgets); diff != "" {
+		t.Error(diff)
+	}
+	if !budgets[0].Exceeded() {
+		t.Error("expected the budget of rule_a to be exceeded")
+	}
+	if budgets[1].Exceeded() {
+		t.Error("expected the budget of rule_d not to be exceeded")
+	}
+
+	if len(rest) != 2 || rest[0].Rule.Name() != "rule_b" || rest[1].Rule.Name() != "rule_c" {
+		t.Errorf("expected issues of rule_b and rule_c to be left, but got %d issues", len(rest))
+	}
+}
+
+func Test_SeverityBudgets(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	issue := func(severity Severity) *Issue {
+		return &Issue{Rule: &budgetTestRule{name: "test_rule", severity: severity}, Message: "test"}
+	}
+	issues := Issues{issue(sdk.ERROR), issue(sdk.WARNING), issue(sdk.WARNING), issue(sdk.NOTICE)}
+
+	tests := []struct {
+		name        string
+		maxErrors   *int
+		maxWarnings *int
+		want        []*Budget
+		wantRest    int
+	}{
+		{
+			name:     "no budgets",
+			want:     []*Budget{},
+			wantRest: 4,
+		},
+		{
+			name:        "errors and warnings",
+			maxErrors:   intPtr(0),
+			maxWarnings: intPtr(2),
+			want: []*Budget{
+				{Name: "errors", Max: 0, Count: 1},
+				{Name: "warnings", Max: 2, Count: 2},
+			},
+			wantRest: 1,
+		},
+		{
+			name:        "warnings only",
+			maxWarnings: intPtr(1),
+			want: []*Budget{
+				{Name: "warnings", Max: 1, Count: 2},
+			},
+			wantRest: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			budgets, rest := SeverityBudgets(issues, test.maxErrors, test.maxWarnings)
+			if diff := cmp.Diff(test.want, budgets); diff != "" {
+				t.Error(diff)
+			}
+			if len(rest) != test.wantRest {
+				t.Errorf("expected %d issues to be left, but got %d", test.wantRest, len(rest))
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package tflint
 import (
 	"fmt"
 	"log"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	hcl "github.com/hashicorp/hcl/v2"
@@ -13,12 +15,19 @@ import (
 	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/didyoumean"
+	"golang.org/x/exp/slices"
 )
 
 var defaultConfigFile = ".tflint.hcl"
+var defaultJSONConfigFile = ".tflint.json"
 var fallbackConfigFile = "~/.tflint.hcl"
+var fallbackJSONConfigFile = "~/.tflint.json"
 
 var configSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "extends"},
+	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
 			Type: "config",
@@ -31,6 +40,40 @@ var configSchema = &hcl.BodySchema{
 			Type:       "plugin",
 			LabelNames: []string{"name"},
 		},
+		{
+			Type: "override",
+		},
+		{
+			Type: "ignore",
+		},
+		{
+			Type:       "profile",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+// profileSchema is the schema of profile blocks.
+// Profiles can contain any blocks except `profile`, and cannot extend other files.
+var profileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: "config",
+		},
+		{
+			Type:       "rule",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "plugin",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type: "override",
+		},
+		{
+			Type: "ignore",
+		},
 	},
 }
 
@@ -44,9 +87,20 @@ var innerConfigSchema = &hcl.BodySchema{
 		{Name: "disabled_by_default"},
 		{Name: "plugin_dir"},
 		{Name: "format"},
+		{Name: "exclude"},
+		{Name: "require_annotation_reason"},
+		{Name: "report_unused_annotations"},
 	},
 }
 
+// validSourceTypes is the types of hosting services where plugins are released.
+// See plugin.NewReleaseProvider for the details of each type.
+var validSourceTypes = []string{
+	"github",
+	"gitlab",
+	"http",
+}
+
 var validFormats = []string{
 	"default",
 	"json",
@@ -73,21 +127,46 @@ type Config struct {
 	Format    string
 	FormatSet bool
 
+	RequireAnnotationReason    bool
+	RequireAnnotationReasonSet bool
+
+	ReportUnusedAnnotations    bool
+	ReportUnusedAnnotationsSet bool
+
 	Varfiles      []string
 	Variables     []string
 	Only          []string
+	Exclude       []string
 	IgnoreModules map[string]bool
 	Rules         map[string]*RuleConfig
 	Plugins       map[string]*PluginConfig
+	Overrides     []*ConfigOverride
+	Ignores       []*ConfigIgnore
 
 	sources map[string][]byte
+	// excludeBaseDirs is the absolute paths of the directories containing the config files
+	// that declare each pattern of Exclude. It is empty for patterns not declared in files.
+	excludeBaseDirs []string
+	// profiles is the named configs layered over this config when selected by ApplyProfile.
+	profiles map[string]*Config
+	// enabledByCLI is the names of rules declared in files whose enabled state is overridden by CLI flags.
+	// Rule patterns in CLI flags don't take precedence over the exact names in CLI flags.
+	enabledByCLI map[string]bool
+	// knownRules is the names of all rules in the loaded rulesets.
+	// It is set by ValidateRules and nil before validation.
+	knownRules []string
+	// ruleRulesets is the names of the rulesets that provide each rule.
+	// It is set by ValidateRules and nil before validation.
+	ruleRulesets map[string]string
 }
 
 // RuleConfig is a TFLint's rule config
 type RuleConfig struct {
-	Name    string   `hcl:"name,label"`
-	Enabled bool     `hcl:"enabled"`
-	Body    hcl.Body `hcl:",remain"`
+	Name      string   `hcl:"name,label"`
+	Enabled   bool     `hcl:"enabled"`
+	Severity  string   `hcl:"severity,optional"`
+	MaxIssues *int     `hcl:"max_issues,optional"`
+	Body      hcl.Body `hcl:",remain"`
 }
 
 // PluginConfig is a TFLint's plugin config
@@ -96,6 +175,7 @@ type PluginConfig struct {
 	Enabled    bool   `hcl:"enabled"`
 	Version    string `hcl:"version,optional"`
 	Source     string `hcl:"source,optional"`
+	SourceType string `hcl:"source_type,optional"`
 	SigningKey string `hcl:"signing_key,optional"`
 
 	Body hcl.Body `hcl:",remain"`
@@ -117,62 +197,172 @@ func EmptyConfig() *Config {
 		DisabledByDefault: false,
 		Rules:             map[string]*RuleConfig{},
 		Plugins:           map[string]*PluginConfig{},
+		profiles:          map[string]*Config{},
 	}
 }
 
-// LoadConfig loads TFLint config file.
+// LoadConfig loads TFLint config files.
 // The priority of the configuration files is as follows:
 //
-// 1. current directory (./.tflint.hcl)
-// 2. home directory (~/.tflint.hcl)
+// 1. current directory (./.tflint.hcl, ./.tflint.json)
+// 2. home directory (~/.tflint.hcl, ~/.tflint.json)
 //
-// If neither file exists, an empty config is returned.
+// If none of the files exist, an empty config is returned.
 // You can also load any file name. However, there is no fallback
 // to the home directory in this case.
 //
+// If multiple files are passed, they are merged in order,
+// so later files take precedence. All of the files must exist.
+//
 // It also automatically enables bundled plugin if the "terraform"
 // plugin block is not explicitly declared.
-func LoadConfig(fs afero.Afero, file string) (*Config, error) {
-	log.Printf("[INFO] Load config: %s", file)
-	if f, err := fs.Open(file); err == nil {
-		cfg, err := loadConfig(f)
+func LoadConfig(fs afero.Afero, files ...string) (*Config, error) {
+	if len(files) > 1 {
+		config := EmptyConfig()
+		for _, file := range files {
+			log.Printf("[INFO] Load config: %s", file)
+			f, err := fs.Open(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load file: %w", err)
+			}
+			cfg, err := loadConfig(fs, f, []string{})
+			if err != nil {
+				return nil, err
+			}
+			config.Merge(cfg)
+		}
+		return config.enableBundledPlugin(), nil
+	}
+
+	file := defaultConfigFile
+	if len(files) == 1 {
+		file = files[0]
+	}
+
+	if file != defaultConfigFile {
+		log.Printf("[INFO] Load config: %s", file)
+		f, err := fs.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load file: %w", err)
+		}
+		cfg, err := loadConfig(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
 		return cfg.enableBundledPlugin(), nil
-	} else if file != defaultConfigFile {
-		return nil, fmt.Errorf("failed to load file: %w", err)
-	} else {
-		log.Printf("[INFO] file not found")
 	}
 
-	fallback, err := homedir.Expand(fallbackConfigFile)
-	if err != nil {
-		return nil, err
-	}
+	// The JSON syntax is looked up after the native syntax in each directory
+	for _, candidate := range []string{defaultConfigFile, defaultJSONConfigFile, fallbackConfigFile, fallbackJSONConfigFile} {
+		path, err := homedir.Expand(candidate)
+		if err != nil {
+			return nil, err
+		}
 
-	log.Printf("[INFO] Load config: %s", fallback)
-	if f, err := fs.Open(fallback); err == nil {
-		cfg, err := loadConfig(f)
+		log.Printf("[INFO] Load config: %s", path)
+		f, err := fs.Open(path)
+		if err != nil {
+			log.Printf("[INFO] file not found")
+			continue
+		}
+		cfg, err := loadConfig(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
 		return cfg.enableBundledPlugin(), nil
 	}
-	log.Printf("[INFO] file not found")
 
 	log.Print("[INFO] Use default config")
 	return EmptyConfig().enableBundledPlugin(), nil
 }
 
-func loadConfig(file afero.File) (*Config, error) {
+// FindConfigFiles walks up from the directory to the repository root,
+// and returns the paths of the default config files (.tflint.hcl or .tflint.json) found.
+// If both exist in a directory, .tflint.hcl is used.
+// The repository root is the first directory containing ".git".
+// If there is no such directory, only the files up to the passed root directory
+// are returned, so that config files outside the project are not picked up.
+//
+// The paths are relative to the passed directory if it is relative, and are ordered
+// from the farthest to the nearest. Passing them to LoadConfig gives precedence to the nearest.
+func FindConfigFiles(fs afero.Afero, dir string, root string) ([]string, error) {
+	start, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	// inRoot is the number of files found in the root directory or its descendants
+	inRoot := 0
+	current := start
+	for {
+		rel, err := filepath.Rel(start, current)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range []string{defaultConfigFile, defaultJSONConfigFile} {
+			exists, err := fs.Exists(filepath.Join(current, name))
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				files = append([]string{filepath.Join(dir, rel, name)}, files...)
+				if !isOutside(root, current) {
+					inRoot++
+				}
+				break
+			}
+		}
+
+		// .git is a file in worktrees and submodules
+		isRepoRoot, err := fs.Exists(filepath.Join(current, ".git"))
+		if err != nil {
+			return nil, err
+		}
+		if isRepoRoot {
+			return files, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	// Without a repository, files outside the root are not used
+	return files[len(files)-inRoot:], nil
+}
+
+// isOutside returns whether the path is outside the directory
+func isOutside(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return true
+	}
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// loadConfig loads the config file and the files it extends.
+// The extended files are merged in order, and then the file itself is merged on top of them.
+// The loading is the absolute paths of the files being loaded, used to detect circular extends.
+func loadConfig(fs afero.Afero, file afero.File, loading []string) (*Config, error) {
 	src, err := afero.ReadAll(file)
 	if err != nil {
 		return nil, err
 	}
 
 	parser := hclparse.NewParser()
-	f, diags := parser.ParseHCL(src, file.Name())
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(file.Name(), ".json") {
+		f, diags = parser.ParseJSON(src, file.Name())
+	} else {
+		f, diags = parser.ParseHCL(src, file.Name())
+	}
 	if diags.HasErrors() {
 		return nil, diags
 	}
@@ -182,87 +372,84 @@ func loadConfig(file afero.File) (*Config, error) {
 		return nil, diags
 	}
 
-	config := EmptyConfig()
-	config.sources = parser.Sources()
-	for _, block := range content.Blocks {
-		switch block.Type {
-		case "config":
-			inner, diags := block.Body.Content(innerConfigSchema)
-			if diags.HasErrors() {
-				return config, diags
-			}
+	baseDir, err := filepath.Abs(filepath.Dir(file.Name()))
+	if err != nil {
+		return nil, err
+	}
+	ctx := ConfigEvalContext(file.Name())
 
-			for name, attr := range inner.Attributes {
-				switch name {
-				case "module":
-					config.ModuleSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Module); err != nil {
-						return config, err
-					}
-				case "force":
-					config.ForceSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Force); err != nil {
-						return config, err
-					}
-				case "ignore_module":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.IgnoreModules); err != nil {
-						return config, err
-					}
-				case "varfile":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Varfiles); err != nil {
-						return config, err
-					}
-				case "variables":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Variables); err != nil {
-						return config, err
-					}
-				case "disabled_by_default":
-					config.DisabledByDefaultSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.DisabledByDefault); err != nil {
-						return config, err
-					}
-				case "plugin_dir":
-					config.PluginDirSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.PluginDir); err != nil {
-						return config, err
-					}
-				case "format":
-					config.FormatSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Format); err != nil {
-						return config, err
-					}
-					formatValid := false
-					for _, f := range validFormats {
-						if config.Format == "" || config.Format == f {
-							formatValid = true
-							break
-						}
-					}
-					if !formatValid {
-						return config, fmt.Errorf("%s is invalid format. Allowed formats are: %s", config.Format, strings.Join(validFormats, ", "))
-					}
-				default:
-					panic("never happened")
+	extended := EmptyConfig()
+	if attr, exists := content.Attributes["extends"]; exists {
+		var extends []string
+		if err := decodeConfigExpression(attr.Expr, ctx, &extends); err != nil {
+			return nil, err
+		}
+
+		path, err := filepath.Abs(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		loading = append(loading, path)
+
+		for _, extend := range extends {
+			extendPath := extend
+			// Relative paths are resolved from the directory of the extending file
+			if !filepath.IsAbs(extendPath) {
+				extendPath = filepath.Join(filepath.Dir(file.Name()), extendPath)
+			}
+			abs, err := filepath.Abs(extendPath)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range loading {
+				if l == abs {
+					return nil, fmt.Errorf("`%s` is extended circularly from `%s`", extendPath, file.Name())
 				}
 			}
-		case "rule":
-			ruleConfig := &RuleConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, ruleConfig); err != nil {
-				return config, err
+
+			log.Printf("[INFO] Load config: %s (extended from %s)", extendPath, file.Name())
+			f, err := fs.Open(extendPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load `%s` extended from `%s`: %w", extendPath, file.Name(), err)
 			}
-			config.Rules[block.Labels[0]] = ruleConfig
-		case "plugin":
-			pluginConfig := &PluginConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, pluginConfig); err != nil {
-				return config, err
+			cfg, err := loadConfig(fs, f, loading)
+			if err != nil {
+				return nil, err
 			}
-			if err := pluginConfig.validate(); err != nil {
+			extended.Merge(cfg)
+		}
+	}
+
+	config := EmptyConfig()
+	config.sources = parser.Sources()
+	for _, block := range content.Blocks {
+		if block.Type != "profile" {
+			if err := decodeConfigBlock(config, block, baseDir, ctx); err != nil {
 				return config, err
 			}
-			config.Plugins[block.Labels[0]] = pluginConfig
-		default:
-			panic("never happened")
+			continue
+		}
+
+		name := block.Labels[0]
+		if _, exists := config.profiles[name]; exists {
+			return config, fmt.Errorf("profile `%s` is declared more than once in %s", name, file.Name())
+		}
+		inner, diags := block.Body.Content(profileSchema)
+		if diags.HasErrors() {
+			return config, diags
+		}
+		profile := EmptyConfig()
+		for _, b := range inner.Blocks {
+			if err := decodeConfigBlock(profile, b, baseDir, ctx); err != nil {
+				return config, fmt.Errorf("profile `%s`: %w", name, err)
+			}
 		}
+		config.profiles[name] = profile
+	}
+
+	if _, exists := content.Attributes["extends"]; exists {
+		extended.Merge(config)
+		config = extended
 	}
 
 	log.Printf("[DEBUG] Config loaded")
@@ -276,25 +463,164 @@ func loadConfig(file afero.File) (*Config, error) {
 	log.Printf("[DEBUG]   PluginDirSet: %t", config.PluginDirSet)
 	log.Printf("[DEBUG]   Format: %s", config.Format)
 	log.Printf("[DEBUG]   FormatSet: %t", config.FormatSet)
+	log.Printf("[DEBUG]   RequireAnnotationReason: %t", config.RequireAnnotationReason)
+	log.Printf("[DEBUG]   RequireAnnotationReasonSet: %t", config.RequireAnnotationReasonSet)
+	log.Printf("[DEBUG]   ReportUnusedAnnotations: %t", config.ReportUnusedAnnotations)
+	log.Printf("[DEBUG]   ReportUnusedAnnotationsSet: %t", config.ReportUnusedAnnotationsSet)
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(config.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(config.Variables, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(config.Only, ", "))
+	log.Printf("[DEBUG]   Exclude: %s", strings.Join(config.Exclude, ", "))
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range config.IgnoreModules {
 		log.Printf("[DEBUG]     %s: %t", name, ignore)
 	}
 	log.Printf("[DEBUG]   Rules:")
 	for name, rule := range config.Rules {
-		log.Printf("[DEBUG]     %s: %t", name, rule.Enabled)
+		if rule.MaxIssues != nil {
+			log.Printf("[DEBUG]     %s: enabled=%t, severity=%s, max_issues=%d", name, rule.Enabled, rule.Severity, *rule.MaxIssues)
+		} else {
+			log.Printf("[DEBUG]     %s: enabled=%t, severity=%s", name, rule.Enabled, rule.Severity)
+		}
 	}
 	log.Printf("[DEBUG]   Plugins:")
 	for name, plugin := range config.Plugins {
 		log.Printf("[DEBUG]     %s: enabled=%t, version=%s, source=%s", name, plugin.Enabled, plugin.Version, plugin.Source)
 	}
+	log.Printf("[DEBUG]   Profiles: %s", strings.Join(config.ProfileNames(), ", "))
+	log.Printf("[DEBUG]   Overrides:")
+	for _, override := range config.Overrides {
+		log.Printf("[DEBUG]     %s:", strings.Join(override.Files, ", "))
+		for name, rule := range override.Rules {
+			log.Printf("[DEBUG]       %s: enabled=%t, severity=%s", name, rule.Enabled, rule.Severity)
+		}
+	}
+	log.Printf("[DEBUG]   Ignores:")
+	for _, ignore := range config.Ignores {
+		log.Printf("[DEBUG]     %s: files=%s, address=%s", ignore.Rule, strings.Join(ignore.Files, ", "), ignore.Address)
+	}
 
 	return config, nil
 }
 
+// decodeConfigBlock decodes the top-level block and sets it to the config.
+// Blocks in profile blocks are decoded in the same way.
+func decodeConfigBlock(config *Config, block *hcl.Block, baseDir string, ctx *hcl.EvalContext) error {
+	switch block.Type {
+	case "config":
+		inner, diags := block.Body.Content(innerConfigSchema)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for name, attr := range inner.Attributes {
+			switch name {
+			case "module":
+				config.ModuleSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Module); err != nil {
+					return err
+				}
+			case "force":
+				config.ForceSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Force); err != nil {
+					return err
+				}
+			case "ignore_module":
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.IgnoreModules); err != nil {
+					return err
+				}
+			case "varfile":
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Varfiles); err != nil {
+					return err
+				}
+			case "variables":
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Variables); err != nil {
+					return err
+				}
+			case "disabled_by_default":
+				config.DisabledByDefaultSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.DisabledByDefault); err != nil {
+					return err
+				}
+			case "plugin_dir":
+				config.PluginDirSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.PluginDir); err != nil {
+					return err
+				}
+			case "format":
+				config.FormatSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Format); err != nil {
+					return err
+				}
+				formatValid := false
+				for _, f := range validFormats {
+					if config.Format == "" || config.Format == f {
+						formatValid = true
+						break
+					}
+				}
+				if !formatValid {
+					return fmt.Errorf("%s is invalid format. Allowed formats are: %s", config.Format, strings.Join(validFormats, ", "))
+				}
+			case "exclude":
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.Exclude); err != nil {
+					return err
+				}
+				config.excludeBaseDirs = make([]string, len(config.Exclude))
+				for i := range config.Exclude {
+					config.excludeBaseDirs[i] = baseDir
+				}
+			case "require_annotation_reason":
+				config.RequireAnnotationReasonSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.RequireAnnotationReason); err != nil {
+					return err
+				}
+			case "report_unused_annotations":
+				config.ReportUnusedAnnotationsSet = true
+				if err := decodeConfigExpression(attr.Expr, ctx, &config.ReportUnusedAnnotations); err != nil {
+					return err
+				}
+			default:
+				panic("never happened")
+			}
+		}
+	case "rule":
+		ruleConfig := &RuleConfig{Name: block.Labels[0]}
+		if err := gohcl.DecodeBody(block.Body, ctx, ruleConfig); err != nil {
+			return err
+		}
+		if err := ruleConfig.validate(); err != nil {
+			return err
+		}
+		config.Rules[block.Labels[0]] = ruleConfig
+	case "plugin":
+		pluginConfig := &PluginConfig{Name: block.Labels[0]}
+		if err := gohcl.DecodeBody(block.Body, ctx, pluginConfig); err != nil {
+			return err
+		}
+		if err := pluginConfig.validate(); err != nil {
+			return err
+		}
+		config.Plugins[block.Labels[0]] = pluginConfig
+	case "override":
+		override, err := decodeOverrideBlock(block, baseDir, ctx)
+		if err != nil {
+			return err
+		}
+		config.Overrides = append(config.Overrides, override)
+	case "ignore":
+		ignore, err := decodeIgnoreBlock(block, baseDir, ctx)
+		if err != nil {
+			return err
+		}
+		config.Ignores = append(config.Ignores, ignore)
+	default:
+		panic("never happened")
+	}
+
+	return nil
+}
+
 // Enable the "recommended" preset if the bundled plugin is automatically enabled.
 var bundledPluginConfigFilename = "__bundled_plugin_config.hcl"
 var bundledPluginConfigContent = `
@@ -332,6 +658,51 @@ func (c *Config) enableBundledPlugin() *Config {
 	return c
 }
 
+// ImplicitPreset returns whether the plugin config is the implicit preset
+// of the bundled plugin, which is set when the plugin block is not declared.
+func (c *PluginConfig) ImplicitPreset() bool {
+	return c.Body != nil && c.Body.MissingItemRange().Filename == bundledPluginConfigFilename
+}
+
+// ApplyProfile layers the named profile over the config.
+// Profiles declared in multiple files are merged in the same order as the files.
+func (c *Config) ApplyProfile(name string) error {
+	profile, exists := c.profiles[name]
+	if !exists {
+		names := c.ProfileNames()
+		if suggestion := didyoumean.NameSuggestion(name, names); suggestion != "" {
+			return fmt.Errorf("profile `%s` is not declared. Did you mean `%s`?", name, suggestion)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("profile `%s` is not declared. No profiles are declared in config files", name)
+		}
+		return fmt.Errorf("profile `%s` is not declared. Available profiles are: %s", name, strings.Join(names, ", "))
+	}
+
+	log.Printf("[INFO] Apply profile: %s", name)
+	c.Merge(profile)
+	return nil
+}
+
+// ProfileNames returns the sorted names of profiles declared in config files.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.profiles))
+	for name := range c.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExcludeBaseDirs returns the base directories of the patterns in Exclude, in the same order.
+// Patterns are relative to the directory containing the config file that declares them.
+// The base directory is empty for patterns not declared in files.
+func (c *Config) ExcludeBaseDirs() []string {
+	ret := make([]string, len(c.Exclude))
+	copy(ret, c.excludeBaseDirs)
+	return ret
+}
+
 // Sources returns parsed config file sources.
 // To support bundle plugin config, this function returns c.sources
 // with a merge of the pseudo config file.
@@ -346,6 +717,17 @@ func (c *Config) Sources() map[string][]byte {
 	return ret
 }
 
+// Files returns the names of the loaded config files in sorted order.
+// Unlike Sources, the pseudo config file of the bundled plugin is not included.
+func (c *Config) Files() []string {
+	ret := make([]string, 0, len(c.sources))
+	for name := range c.sources {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 // Merge merges the two configs and applies to itself.
 // Since the argument takes precedence, it can be used as overwriting of the config.
 func (c *Config) Merge(other *Config) {
@@ -369,10 +751,20 @@ func (c *Config) Merge(other *Config) {
 		c.FormatSet = true
 		c.Format = other.Format
 	}
+	if other.RequireAnnotationReasonSet {
+		c.RequireAnnotationReasonSet = true
+		c.RequireAnnotationReason = other.RequireAnnotationReason
+	}
+	if other.ReportUnusedAnnotationsSet {
+		c.ReportUnusedAnnotationsSet = true
+		c.ReportUnusedAnnotations = other.ReportUnusedAnnotations
+	}
 
 	c.Varfiles = append(c.Varfiles, other.Varfiles...)
 	c.Variables = append(c.Variables, other.Variables...)
 	c.Only = append(c.Only, other.Only...)
+	c.excludeBaseDirs = append(c.ExcludeBaseDirs(), other.ExcludeBaseDirs()...)
+	c.Exclude = append(c.Exclude, other.Exclude...)
 
 	for name, ignore := range other.IgnoreModules {
 		c.IgnoreModules[name] = ignore
@@ -381,8 +773,15 @@ func (c *Config) Merge(other *Config) {
 	for name, rule := range other.Rules {
 		// HACK: If you enable the rule through the CLI instead of the file, its hcl.Body will be nil.
 		//       In this case, only override Enabled flag
-		if _, exists := c.Rules[name]; exists && rule.Body == nil {
+		if base, exists := c.Rules[name]; exists && rule.Body == nil {
 			c.Rules[name].Enabled = rule.Enabled
+			if c.enabledByCLI == nil {
+				c.enabledByCLI = map[string]bool{}
+			}
+			c.enabledByCLI[name] = true
+		} else if exists && base.Body != nil {
+			// Both are declared in files, so layer the other rule config on top of the base
+			c.Rules[name] = base.merge(rule)
 		} else {
 			c.Rules[name] = rule
 		}
@@ -391,12 +790,68 @@ func (c *Config) Merge(other *Config) {
 	for name, plugin := range other.Plugins {
 		// HACK: If you enable the plugin through the CLI instead of the file, its hcl.Body will be nil.
 		//       In this case, only override Enabled flag
-		if _, exists := c.Plugins[name]; exists && plugin.Body == nil {
+		if base, exists := c.Plugins[name]; exists && plugin.Body == nil {
 			c.Plugins[name].Enabled = plugin.Enabled
+		} else if exists && base.Body != nil {
+			// Both are declared in files, so layer the other plugin config on top of the base
+			c.Plugins[name] = base.merge(plugin)
 		} else {
 			c.Plugins[name] = plugin
 		}
 	}
+
+	c.Overrides = append(c.Overrides, other.Overrides...)
+	c.Ignores = append(c.Ignores, other.Ignores...)
+
+	for name, profile := range other.profiles {
+		if c.profiles == nil {
+			c.profiles = map[string]*Config{}
+		}
+		if base, exists := c.profiles[name]; exists {
+			base.Merge(profile)
+		} else {
+			c.profiles[name] = profile
+		}
+	}
+
+	if len(other.sources) > 0 {
+		if c.sources == nil {
+			c.sources = map[string][]byte{}
+		}
+		for name, src := range other.sources {
+			c.sources[name] = src
+		}
+	}
+}
+
+// merge returns a new rule config that layers the other on top of the receiver.
+// Attributes omitted in the other are inherited, and the bodies are merged.
+func (c *RuleConfig) merge(other *RuleConfig) *RuleConfig {
+	ret := *other
+	if ret.Severity == "" {
+		ret.Severity = c.Severity
+	}
+	if ret.MaxIssues == nil {
+		ret.MaxIssues = c.MaxIssues
+	}
+	ret.Body = mergeBodies(c.Body, other.Body)
+	return &ret
+}
+
+// merge returns a new plugin config that layers the other on top of the receiver.
+// The source is inherited if omitted in the other, and the bodies are merged.
+func (c *PluginConfig) merge(other *PluginConfig) *PluginConfig {
+	ret := *other
+	if ret.Source == "" {
+		ret.Version = c.Version
+		ret.Source = c.Source
+		ret.SourceType = c.SourceType
+		ret.SigningKey = c.SigningKey
+		ret.SourceOwner = c.SourceOwner
+		ret.SourceRepo = c.SourceRepo
+	}
+	ret.Body = mergeBodies(c.Body, other.Body)
+	return &ret
 }
 
 // ToPluginConfig converts self into the plugin configuration format
@@ -416,6 +871,7 @@ func (c *Config) ToPluginConfig() *sdk.Config {
 }
 
 // Content extracts a plugin config based on the passed schema.
+// Expressions that call functions are evaluated and bound to the values.
 func (c *PluginConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent, hcl.Diagnostics) {
 	if schema == nil {
 		schema = &hclext.BodySchema{}
@@ -423,7 +879,25 @@ func (c *PluginConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent,
 	if c.Body == nil {
 		return &hclext.BodyContent{}, hcl.Diagnostics{}
 	}
-	return hclext.Content(c.Body, schema)
+	content, diags := hclext.Content(c.Body, schema)
+	if diags.HasErrors() {
+		return content, diags
+	}
+	return content, bindConfigValues(content)
+}
+
+// Content extracts a rule config based on the passed schema.
+// Expressions that call functions are evaluated and bound to the values.
+func (c *RuleConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent, hcl.Diagnostics) {
+	body := c.Body
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+	content, diags := hclext.Content(body, schema)
+	if diags.HasErrors() {
+		return content, diags
+	}
+	return content, bindConfigValues(content)
 }
 
 // RuleSet is an interface to handle plugin's RuleSet.
@@ -435,35 +909,117 @@ type RuleSet interface {
 }
 
 // ValidateRules checks for duplicate rule names, for invalid rule names, and so on.
+// The rule names found are kept to detect unknown rules in annotations.
 func (c *Config) ValidateRules(rulesets ...RuleSet) error {
+	rulesMap, err := ruleRulesetMap(rulesets...)
+	if err != nil {
+		return err
+	}
+
+	knownRules := make([]string, 0, len(rulesMap))
+	for rule := range rulesMap {
+		knownRules = append(knownRules, rule)
+	}
+	sort.Strings(knownRules)
+
+	for _, rule := range c.Rules {
+		if _, exists := rulesMap[rule.Name]; !exists {
+			return ruleNotFoundError(rule.Name, knownRules)
+		}
+	}
+	for _, override := range c.Overrides {
+		for _, rule := range override.Rules {
+			if _, exists := rulesMap[rule.Name]; !exists {
+				return ruleNotFoundError(rule.Name, knownRules)
+			}
+		}
+	}
+
+	c.knownRules = knownRules
+	c.ruleRulesets = rulesMap
+
+	return nil
+}
+
+// ruleRulesetMap returns the names of all rules in the rulesets mapped to their ruleset names.
+// Core rules are provided by "core".
+func ruleRulesetMap(rulesets ...RuleSet) (map[string]string, error) {
 	rulesMap := map[string]string{}
+	for _, rule := range coreRules {
+		rulesMap[rule.name] = "core"
+	}
 	for _, ruleset := range rulesets {
 		rulesetName, err := ruleset.RuleSetName()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ruleNames, err := ruleset.RuleNames()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		for _, rule := range ruleNames {
 			if existsName, exists := rulesMap[rule]; exists {
-				return fmt.Errorf("`%s` is duplicated in %s and %s", rule, existsName, rulesetName)
+				return nil, fmt.Errorf("`%s` is duplicated in %s and %s", rule, existsName, rulesetName)
 			}
 			rulesMap[rule] = rulesetName
 		}
 	}
+	return rulesMap, nil
+}
 
-	for _, rule := range c.Rules {
-		if _, exists := rulesMap[rule.Name]; !exists {
-			return fmt.Errorf("Rule not found: %s", rule.Name)
-		}
+func ruleNotFoundError(name string, knownRules []string) error {
+	if suggestion := didyoumean.NameSuggestion(name, knownRules); suggestion != "" {
+		return fmt.Errorf("Rule not found: %s. Did you mean `%s`?", name, suggestion)
+	}
+	return fmt.Errorf("Rule not found: %s", name)
+}
+
+// RuleRulesets returns the names of the rulesets that provide each rule.
+// Core rules are provided by "core". It returns nil before ValidateRules.
+func (c *Config) RuleRulesets() map[string]string {
+	return c.ruleRulesets
+}
+
+// applySeverity returns the rule with the severity overridden by the rule config.
+// The rule is returned as is if the config is nil or the severity is not configured.
+func (c *RuleConfig) applySeverity(rule Rule) Rule {
+	if c == nil || c.Severity == "" {
+		return rule
+	}
+	severity, err := NewSeverity(c.Severity)
+	if err != nil {
+		// The severity is validated when loading the config
+		panic(err)
 	}
+	return &severityOverriddenRule{Rule: rule, severity: severity}
+}
 
+func (c *RuleConfig) validate() error {
+	if c.Severity != "" {
+		if _, err := NewSeverity(c.Severity); err != nil {
+			return fmt.Errorf("rule `%s`: `severity` is invalid. Allowed values are: error, warning, notice", c.Name)
+		}
+	}
+	if c.MaxIssues != nil && *c.MaxIssues < 0 {
+		return fmt.Errorf("rule `%s`: `max_issues` must be zero or more", c.Name)
+	}
 	return nil
 }
 
+// EffectiveSourceType returns the type of the hosting service where the plugin is released.
+// If `source_type` is omitted, plugins on gitlab.com are released on GitLab,
+// and plugins on other hosts are released on GitHub or GitHub Enterprise Server.
+func (c *PluginConfig) EffectiveSourceType() string {
+	if c.SourceType != "" {
+		return c.SourceType
+	}
+	if strings.HasPrefix(c.Source, "gitlab.com/") {
+		return "gitlab"
+	}
+	return "github"
+}
+
 func (c *PluginConfig) validate() error {
 	if c.Version != "" && c.Source == "" {
 		return fmt.Errorf("plugin `%s`: `source` attribute cannot be omitted when specifying `version`", c.Name)
@@ -474,16 +1030,31 @@ func (c *PluginConfig) validate() error {
 			return fmt.Errorf("plugin `%s`: `version` attribute cannot be omitted when specifying `source`", c.Name)
 		}
 
-		parts := strings.Split(c.Source, "/")
-		// Expected `github.com/owner/repo` format
-		if len(parts) != 3 {
-			return fmt.Errorf("plugin `%s`: `source` is invalid. Must be in the format `github.com/owner/repo`", c.Name)
+		if c.SourceType != "" && !slices.Contains(validSourceTypes, c.SourceType) {
+			return fmt.Errorf("plugin `%s`: `source_type` is invalid. Allowed values are: %s", c.Name, strings.Join(validSourceTypes, ", "))
 		}
-		if parts[0] != "github.com" {
-			return fmt.Errorf("plugin `%s`: `source` is invalid. Hostname must be `github.com`", c.Name)
+
+		parts := strings.Split(c.Source, "/")
+		host := parts[0]
+		switch c.EffectiveSourceType() {
+		case "gitlab":
+			// Expected `gitlab.com/namespace/project` format. Namespaces can be nested groups.
+			if len(parts) < 3 {
+				return fmt.Errorf("plugin `%s`: `source` is invalid. Must be in the format `%s/namespace/project`", c.Name, host)
+			}
+		case "http":
+			// Expected `example.com/path` format
+			if len(parts) < 2 {
+				return fmt.Errorf("plugin `%s`: `source` is invalid. Must be in the format `%s/path`", c.Name, host)
+			}
+		default:
+			// Expected `github.com/owner/repo` format
+			if len(parts) != 3 {
+				return fmt.Errorf("plugin `%s`: `source` is invalid. Must be in the format `%s/owner/repo`", c.Name, host)
+			}
 		}
-		c.SourceOwner = parts[1]
-		c.SourceRepo = parts[2]
+		c.SourceOwner = strings.Join(parts[1:len(parts)-1], "/")
+		c.SourceRepo = parts[len(parts)-1]
 	}
 
 	return nil
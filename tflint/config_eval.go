@@ -0,0 +1,145 @@
+package tflint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/terraform-linters/tflint/terraform/lang/funcs"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// ConfigEvalContext returns the context to evaluate expressions in config files.
+// Unlike Terraform configurations, only functions that read the environment
+// and manipulate strings are available, and `terraform.workspace` is the only variable.
+//
+// Relative paths in the file function are resolved from the directory of the passed file.
+func ConfigEvalContext(filename string) *hcl.EvalContext {
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"terraform": cty.ObjectVal(map[string]cty.Value{
+				"workspace": cty.StringVal(terraform.Workspace()),
+			}),
+		},
+		Functions: map[string]function.Function{
+			"env":        envFunc,
+			"file":       funcs.MakeFileFunc(filepath.Dir(filename), false),
+			"chomp":      stdlib.ChompFunc,
+			"coalesce":   funcs.CoalesceFunc,
+			"endswith":   funcs.EndsWithFunc,
+			"format":     stdlib.FormatFunc,
+			"join":       stdlib.JoinFunc,
+			"lower":      stdlib.LowerFunc,
+			"replace":    funcs.ReplaceFunc,
+			"split":      stdlib.SplitFunc,
+			"startswith": funcs.StartsWithFunc,
+			"substr":     stdlib.SubstrFunc,
+			"title":      stdlib.TitleFunc,
+			"trim":       stdlib.TrimFunc,
+			"trimprefix": stdlib.TrimPrefixFunc,
+			"trimspace":  stdlib.TrimSpaceFunc,
+			"trimsuffix": stdlib.TrimSuffixFunc,
+			"upper":      stdlib.UpperFunc,
+		},
+	}
+}
+
+// envFunc returns the value of the environment variable.
+// If the variable is not set, it returns the second argument as the default value,
+// or an error if no default is passed. An empty value is a valid value.
+var envFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "default",
+		Type: cty.String,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(2, "too many arguments; only a name and a default value are allowed")
+		}
+
+		name := args[0].AsString()
+		if val, exists := os.LookupEnv(name); exists {
+			return cty.StringVal(val), nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return cty.UnknownVal(cty.String), fmt.Errorf("environment variable %q is not set. Set the variable or pass a default value as the second argument", name)
+	},
+})
+
+// bindConfigValues evaluates expressions in the plugin or rule config and binds the values to them.
+// Plugins decode their config without an evaluation context, so expressions that call functions
+// or refer to `terraform.workspace` are evaluated by TFLint in advance.
+// Other expressions are passed as is, except that expressions in JSON config files are
+// wrapped so that plugins can parse them.
+func bindConfigValues(content *hclext.BodyContent) hcl.Diagnostics {
+	diags := hcl.Diagnostics{}
+
+	for _, attr := range content.Attributes {
+		if isJSONConfigFile(attr.Expr.Range().Filename) {
+			attr.Expr = &pluginJSONExpr{Expression: attr.Expr}
+		}
+		if !needsConfigEval(attr.Expr) {
+			continue
+		}
+		val, moreDiags := attr.Expr.Value(ConfigEvalContext(attr.Expr.Range().Filename))
+		diags = diags.Extend(moreDiags)
+		if moreDiags.HasErrors() {
+			continue
+		}
+		attr.Expr = hclext.BindValue(val, attr.Expr)
+	}
+	for _, block := range content.Blocks {
+		diags = diags.Extend(bindConfigValues(block.Body))
+	}
+
+	return diags
+}
+
+// needsConfigEval returns true if the expression cannot be evaluated without the config eval context.
+func needsConfigEval(expr hcl.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == "terraform" {
+			return true
+		}
+	}
+
+	if _, ok := expr.(hclsyntax.Expression); !ok {
+		// Expressions in other syntaxes cannot be walked. Strings in the JSON syntax are interpreted
+		// as templates only when a context is passed, so they are always evaluated.
+		return true
+	}
+
+	found := false
+	hclsyntax.VisitAll(expr.(hclsyntax.Expression), func(node hclsyntax.Node) hcl.Diagnostics {
+		if _, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// decodeConfigExpression decodes the expression like gohcl.DecodeExpression,
+// but returns evaluation errors as is without redundant diagnostics about unknown values.
+func decodeConfigExpression(expr hcl.Expression, ctx *hcl.EvalContext, val interface{}) hcl.Diagnostics {
+	if _, diags := expr.Value(ctx); diags.HasErrors() {
+		return diags
+	}
+	return gohcl.DecodeExpression(expr, ctx, val)
+}
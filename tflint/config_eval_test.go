@@ -0,0 +1,170 @@
+package tflint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+func TestLoadConfig_functions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		env     map[string]string
+		want    func(*Config) bool
+		wantErr string
+	}{
+		{
+			name: "env and terraform.workspace",
+			config: `
+config {
+  plugin_dir = env("TFLINT_TEST_PLUGIN_DIR")
+  varfile    = ["${terraform.workspace}.tfvars"]
+  format     = lower(env("TFLINT_TEST_FORMAT", "COMPACT"))
+}`,
+			env: map[string]string{
+				"TFLINT_TEST_PLUGIN_DIR": "/opt/tflint/plugins",
+				"TF_WORKSPACE":           "staging",
+			},
+			want: func(c *Config) bool {
+				return c.PluginDir == "/opt/tflint/plugins" && cmp.Equal(c.Varfiles, []string{"staging.tfvars"}) && c.Format == "compact"
+			},
+		},
+		{
+			name: "file relative to the config",
+			config: `
+config {
+  exclude = split("\n", trimspace(file("exclude.txt")))
+}`,
+			want: func(c *Config) bool {
+				return cmp.Equal(c.Exclude, []string{"examples/**", "legacy/**"})
+			},
+		},
+		{
+			name: "env in rule blocks",
+			config: `
+rule "aws_instance_invalid_type" {
+  enabled  = env("TFLINT_TEST_ENABLED") == "true"
+  severity = "warning"
+}`,
+			env: map[string]string{"TFLINT_TEST_ENABLED": "true"},
+			want: func(c *Config) bool {
+				return c.Rules["aws_instance_invalid_type"].Enabled
+			},
+		},
+		{
+			name: "unset environment variable",
+			config: `
+config {
+  plugin_dir = env("TFLINT_TEST_UNSET")
+}`,
+			wantErr: `Call to function "env" failed: environment variable "TFLINT_TEST_UNSET" is not set. Set the variable or pass a default value as the second argument.`,
+		},
+		{
+			name: "unsupported function",
+			config: `
+config {
+  plugin_dir = abspath("plugins")
+}`,
+			wantErr: `There is no function named "abspath".`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Unsetenv("TF_WORKSPACE")
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, ".tflint.hcl"), []byte(test.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "exclude.txt"), []byte("examples/**\nlegacy/**\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, filepath.Join(dir, ".tflint.hcl"))
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected an error containing %q, but got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !test.want(config) {
+				t.Errorf("unexpected config: %#v", config)
+			}
+		})
+	}
+}
+
+func TestContent_functions(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile(".tflint.hcl", []byte(`
+plugin "aws" {
+  enabled = true
+  region  = env("AWS_REGION")
+  profile = "default"
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = true
+  types   = split(",", "t2.micro,t3.micro")
+
+  nested {
+    region = upper(env("AWS_REGION"))
+  }
+}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(fs, ".tflint.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugin, diags := config.Plugins["aws"].Content(&hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "region"}, {Name: "profile"}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if _, ok := plugin.Attributes["region"].Expr.(*hclext.BoundExpr); !ok {
+		t.Errorf("region is not bound: %T", plugin.Attributes["region"].Expr)
+	}
+	if _, ok := plugin.Attributes["profile"].Expr.(*hclext.BoundExpr); ok {
+		t.Error("profile should not be bound because it is a literal")
+	}
+	region, _ := plugin.Attributes["region"].Expr.Value(nil)
+	if region.AsString() != "us-east-1" {
+		t.Errorf("unexpected region: %s", region.GoString())
+	}
+
+	rule, diags := config.Rules["aws_instance_invalid_type"].Content(&hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "types"}},
+		Blocks: []hclext.BlockSchema{
+			{Type: "nested", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "region"}}}},
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	types, _ := rule.Attributes["types"].Expr.Value(nil)
+	if types.LengthInt() != 2 {
+		t.Errorf("unexpected types: %s", types.GoString())
+	}
+	nested, _ := rule.Blocks[0].Body.Attributes["region"].Expr.Value(nil)
+	if nested.AsString() != "US-EAST-1" {
+		t.Errorf("unexpected nested region: %s", nested.GoString())
+	}
+}
@@ -0,0 +1,174 @@
+package tflint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+var ignoreBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "rule", Required: true},
+		{Name: "files"},
+		{Name: "message_regex"},
+		{Name: "address"},
+		{Name: "reason"},
+		{Name: "until"},
+	},
+}
+
+// ConfigIgnore suppresses issues like annotations, but is declared in config files.
+// This is useful for files that cannot have annotations, such as generated files.
+// An issue is suppressed if it matches all of the conditions declared.
+type ConfigIgnore struct {
+	// Rule is the name of the rule to ignore, or "all".
+	Rule string
+	// Files is patterns of files, relative to the directory containing the config file.
+	Files []string
+	// MessageRegex is matched against the issue message. Nil if not declared.
+	MessageRegex *regexp.Regexp
+	// Address is a pattern of the address of the object enclosing the issue,
+	// like "module.legacy.*". "*" matches any characters.
+	Address string
+	// Reason is the justification for ignoring the issues.
+	Reason string
+	// Until is the date on which the ignore expires.
+	// The zero value means that the ignore never expires.
+	Until time.Time
+	// DeclRange is the range of the block header.
+	DeclRange hcl.Range
+
+	// baseDir is the absolute path of the directory containing the config file.
+	baseDir string
+	// addressPattern is the compiled Address.
+	addressPattern *regexp.Regexp
+}
+
+func decodeIgnoreBlock(block *hcl.Block, baseDir string, ctx *hcl.EvalContext) (*ConfigIgnore, error) {
+	content, diags := block.Body.Content(ignoreBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ignore := &ConfigIgnore{DeclRange: block.DefRange, baseDir: baseDir}
+	if err := decodeConfigExpression(content.Attributes["rule"].Expr, ctx, &ignore.Rule); err != nil {
+		return nil, err
+	}
+
+	if attr, exists := content.Attributes["files"]; exists {
+		if err := decodeConfigExpression(attr.Expr, ctx, &ignore.Files); err != nil {
+			return nil, err
+		}
+		for _, pattern := range ignore.Files {
+			// Match the pattern against itself to walk all path segments
+			if _, err := doublestar.Match(pattern, pattern); err != nil {
+				return nil, fmt.Errorf("ignore: `%s` is an invalid file pattern; %w", pattern, err)
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["message_regex"]; exists {
+		var pattern string
+		if err := decodeConfigExpression(attr.Expr, ctx, &pattern); err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("ignore: `%s` is an invalid regular expression; %w", pattern, err)
+		}
+		ignore.MessageRegex = re
+	}
+
+	if attr, exists := content.Attributes["address"]; exists {
+		if err := decodeConfigExpression(attr.Expr, ctx, &ignore.Address); err != nil {
+			return nil, err
+		}
+		ignore.addressPattern = addressPattern(ignore.Address)
+	}
+
+	if attr, exists := content.Attributes["reason"]; exists {
+		if err := decodeConfigExpression(attr.Expr, ctx, &ignore.Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	if attr, exists := content.Attributes["until"]; exists {
+		var until string
+		if err := decodeConfigExpression(attr.Expr, ctx, &until); err != nil {
+			return nil, err
+		}
+		t, err := time.ParseInLocation(annotationDateLayout, until, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("ignore: `until` must be a date in the YYYY-MM-DD format, but got `%s`", until)
+		}
+		ignore.Until = t
+	}
+
+	return ignore, nil
+}
+
+// instanceKeyPattern matches instance keys in addresses, like `["a"]` and `[0]`.
+// Addresses without instance keys are also matched against patterns,
+// so that "module.legacy.*" matches all instances of the module.
+var instanceKeyPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+// addressPattern compiles the address pattern to a regular expression.
+// "*" matches any characters, including dots and brackets of instance keys.
+func addressPattern(address string) *regexp.Regexp {
+	parts := strings.Split(address, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// IsAffected checks if the passed issue is affected by the ignore.
+// The path is the absolute path of the file where the issue is located,
+// and the address returns the address of the object enclosing the issue.
+// The address is called only if `address` is declared, as it is costly to compute.
+func (i *ConfigIgnore) IsAffected(issue *Issue, path string, address func() string) bool {
+	if i.Rule != "all" && i.Rule != issue.Rule.Name() {
+		return false
+	}
+	if len(i.Files) > 0 && !i.MatchFile(path) {
+		return false
+	}
+	if i.MessageRegex != nil && !i.MessageRegex.MatchString(issue.Message) {
+		return false
+	}
+	if i.addressPattern != nil {
+		addr := address()
+		if !i.addressPattern.MatchString(addr) && !i.addressPattern.MatchString(instanceKeyPattern.ReplaceAllString(addr, "")) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchFile returns whether the passed file matches any of the file patterns.
+// The path must be absolute. Files outside the base directory never match.
+// If no patterns are declared, all files match.
+func (i *ConfigIgnore) MatchFile(path string) bool {
+	if len(i.Files) == 0 {
+		return true
+	}
+	return matchFilePatterns(i.Files, i.baseDir, path)
+}
+
+// IsExpired checks if the ignore has expired at the passed time.
+// The ignore is effective until the end of the day specified by `until`.
+func (i *ConfigIgnore) IsExpired(now time.Time) bool {
+	if i.Until.IsZero() {
+		return false
+	}
+	return !now.Before(i.Until.AddDate(0, 0, 1))
+}
+
+// String returns the string representation of the ignore
+func (i *ConfigIgnore) String() string {
+	return fmt.Sprintf("ignore:%s (%s)", i.Rule, i.DeclRange.String())
+}
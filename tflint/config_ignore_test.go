@@ -0,0 +1,193 @@
+package tflint
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+)
+
+func TestLoadConfig_ignores(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*ConfigIgnore
+		errMsg  string
+	}{
+		{
+			name: "ignore blocks",
+			content: `
+ignore {
+  rule          = "aws_instance_invalid_type"
+  files         = ["gen/**"]
+  message_regex = "^\"t1\\."
+  address       = "module.legacy.*"
+  reason        = "generated by the legacy tool"
+  until         = "2030-01-01"
+}
+
+ignore {
+  rule = "all"
+}`,
+			want: []*ConfigIgnore{
+				{
+					Rule:    "aws_instance_invalid_type",
+					Files:   []string{"gen/**"},
+					Address: "module.legacy.*",
+					Reason:  "generated by the legacy tool",
+					Until:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+				},
+				{
+					Rule: "all",
+				},
+			},
+		},
+		{
+			name: "missing rule",
+			content: `
+ignore {
+  files = ["gen/**"]
+}`,
+			errMsg: `.tflint.hcl:2,8-8: Missing required argument; The argument "rule" is required, but no definition was found.`,
+		},
+		{
+			name: "invalid file pattern",
+			content: `
+ignore {
+  rule  = "all"
+  files = ["gen/["]
+}`,
+			errMsg: "ignore: `gen/[` is an invalid file pattern; syntax error in pattern",
+		},
+		{
+			name: "invalid regular expression",
+			content: `
+ignore {
+  rule          = "all"
+  message_regex = "("
+}`,
+			errMsg: "ignore: `(` is an invalid regular expression; error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "invalid date",
+			content: `
+ignore {
+  rule  = "all"
+  until = "2030/01/01"
+}`,
+			errMsg: "ignore: `until` must be a date in the YYYY-MM-DD format, but got `2030/01/01`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			if err := fs.WriteFile(".tflint.hcl", []byte(test.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(fs, ".tflint.hcl")
+			if test.errMsg != "" {
+				if err == nil || err.Error() != test.errMsg {
+					t.Fatalf("expected %q, but got %v", test.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := cmp.Options{
+				cmp.Comparer(func(x, y ConfigIgnore) bool {
+					return x.Rule == y.Rule && cmp.Equal(x.Files, y.Files) && x.Address == y.Address && x.Reason == y.Reason && x.Until.Equal(y.Until)
+				}),
+			}
+			if diff := cmp.Diff(test.want, config.Ignores, opts); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigIgnore_IsAffected(t *testing.T) {
+	base, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile(".tflint.hcl", []byte(`
+ignore {
+  rule          = "test_rule"
+  files         = ["gen/**"]
+  message_regex = "^This is"
+  address       = "module.legacy.*"
+}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(fs, ".tflint.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ignore := config.Ignores[0]
+
+	tests := []struct {
+		name    string
+		path    string
+		message string
+		address string
+		want    bool
+	}{
+		{
+			name:    "match",
+			path:    filepath.Join(base, "gen", "main.tf"),
+			message: "This is test message",
+			address: `module.legacy["a"].aws_instance.web`,
+			want:    true,
+		},
+		{
+			name:    "other file",
+			path:    filepath.Join(base, "main.tf"),
+			message: "This is test message",
+			address: `module.legacy["a"].aws_instance.web`,
+			want:    false,
+		},
+		{
+			name:    "other message",
+			path:    filepath.Join(base, "gen", "main.tf"),
+			message: "That is test message",
+			address: `module.legacy["a"].aws_instance.web`,
+			want:    false,
+		},
+		{
+			name:    "other address",
+			path:    filepath.Join(base, "gen", "main.tf"),
+			message: "This is test message",
+			address: "aws_instance.web",
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issue := &Issue{Rule: &testRule{}, Message: test.message}
+			if got := ignore.IsAffected(issue, test.path, func() string { return test.address }); got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_ConfigIgnore_IsAffected_withoutAddress(t *testing.T) {
+	ignore := &ConfigIgnore{Rule: "test_rule"}
+	issue := &Issue{Rule: &testRule{}, Message: "This is test message"}
+
+	got := ignore.IsAffected(issue, "main.tf", func() string {
+		t.Fatal("the address should not be computed")
+		return ""
+	})
+	if !got {
+		t.Error("expected the issue to be affected")
+	}
+}
@@ -0,0 +1,43 @@
+package tflint
+
+import (
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// pluginJSONExpr is an expression in config files written in the JSON syntax, such as .tflint.json.
+//
+// Plugins parse expressions in the passed config again, choosing the syntax by the file extension,
+// and they only accept `.tf.json` as the JSON syntax. To pass the expressions to them, the range
+// refers to a pseudo file name with the extension. Sources are still looked up by the range of
+// the attribute, so the pseudo file doesn't need to exist.
+type pluginJSONExpr struct {
+	hcl.Expression
+}
+
+var _ hcl.Expression = (*pluginJSONExpr)(nil)
+
+// isJSONConfigFile returns true if the file is a config file written in the JSON syntax.
+// Terraform's JSON files are not config files and are passed to plugins as is.
+func isJSONConfigFile(filename string) bool {
+	return strings.HasSuffix(filename, ".json") && !strings.HasSuffix(filename, ".tf.json")
+}
+
+// pluginJSONFilename returns the pseudo file name of the JSON config file passed to plugins.
+// For example, ".tflint.json" is passed as ".tflint.tf.json".
+func pluginJSONFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".json") + ".tf.json"
+}
+
+func (e *pluginJSONExpr) Range() hcl.Range {
+	rng := e.Expression.Range()
+	rng.Filename = pluginJSONFilename(rng.Filename)
+	return rng
+}
+
+func (e *pluginJSONExpr) StartRange() hcl.Range {
+	rng := e.Expression.StartRange()
+	rng.Filename = pluginJSONFilename(rng.Filename)
+	return rng
+}
@@ -0,0 +1,90 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin/fromproto"
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin/toproto"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestContent_json(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile(".tflint.json", []byte(`{
+  "plugin": {
+    "aws": {
+      "enabled": true,
+      "region": "${env(\"AWS_REGION\")}",
+      "deep_check": true,
+      "tags": ["foo", "bar"]
+    }
+  },
+  "rule": {
+    "aws_resource_missing_tags": {
+      "enabled": true,
+      "tags": ["Name"]
+    }
+  }
+}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(fs, ".tflint.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pluginContent, diags := config.Plugins["aws"].Content(&hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "region"}, {Name: "deep_check"}, {Name: "tags"}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ruleContent, diags := config.Rules["aws_resource_missing_tags"].Content(&hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	// Plugins receive the content encoded with the sources, and decode it in the same way
+	decode := func(content *hclext.BodyContent) map[string]cty.Value {
+		decoded, diags := fromproto.BodyContent(toproto.BodyContent(content, config.Sources()))
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		ret := map[string]cty.Value{}
+		for name, attr := range decoded.Attributes {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			ret[name] = val
+		}
+		return ret
+	}
+
+	want := map[string]cty.Value{
+		"region":     cty.StringVal("us-east-1"),
+		"deep_check": cty.True,
+		"tags":       cty.TupleVal([]cty.Value{cty.StringVal("foo"), cty.StringVal("bar")}),
+	}
+	if diff := cmp.Diff(want, decode(pluginContent), cmp.Comparer(cty.Value.RawEquals)); diff != "" {
+		t.Error(diff)
+	}
+	want = map[string]cty.Value{
+		"tags": cty.TupleVal([]cty.Value{cty.StringVal("Name")}),
+	}
+	if diff := cmp.Diff(want, decode(ruleContent), cmp.Comparer(cty.Value.RawEquals)); diff != "" {
+		t.Error(diff)
+	}
+
+	if got := ruleContent.Attributes["tags"].Expr.Range().Filename; got != ".tflint.tf.json" {
+		t.Errorf("expected the pseudo file name for plugins, but got %s", got)
+	}
+}
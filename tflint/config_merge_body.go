@@ -0,0 +1,125 @@
+package tflint
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// mergedBody is a body that layers the override body on top of the base body.
+// Attributes in the override take precedence over attributes with the same name in the base,
+// and blocks in the override replace all blocks of the same type in the base.
+//
+// This is used to merge rule and plugin blocks declared in multiple config files.
+type mergedBody struct {
+	base     hcl.Body
+	override hcl.Body
+}
+
+var _ hcl.Body = (*mergedBody)(nil)
+
+// mergeBodies returns a body that merges the passed bodies.
+// If either body is nil, the other is returned as is.
+func mergeBodies(base hcl.Body, override hcl.Body) hcl.Body {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+	return &mergedBody{base: base, override: override}
+}
+
+func (b *mergedBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, _, diags := b.content(schema, false)
+	return content, diags
+}
+
+func (b *mergedBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	return b.content(schema, true)
+}
+
+func (b *mergedBody) content(schema *hcl.BodySchema, partial bool) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	// Required attributes can be declared in either body,
+	// so extract contents as optional and check the merged result.
+	optionalSchema := &hcl.BodySchema{Blocks: schema.Blocks}
+	for _, attr := range schema.Attributes {
+		optionalSchema.Attributes = append(optionalSchema.Attributes, hcl.AttributeSchema{Name: attr.Name})
+	}
+
+	var baseContent, overrideContent *hcl.BodyContent
+	var baseRemain, overrideRemain hcl.Body
+	var diags, moreDiags hcl.Diagnostics
+	if partial {
+		baseContent, baseRemain, moreDiags = b.base.PartialContent(optionalSchema)
+		diags = diags.Extend(moreDiags)
+		overrideContent, overrideRemain, moreDiags = b.override.PartialContent(optionalSchema)
+		diags = diags.Extend(moreDiags)
+	} else {
+		baseContent, moreDiags = b.base.Content(optionalSchema)
+		diags = diags.Extend(moreDiags)
+		overrideContent, moreDiags = b.override.Content(optionalSchema)
+		diags = diags.Extend(moreDiags)
+	}
+	if diags.HasErrors() {
+		return &hcl.BodyContent{}, b, diags
+	}
+
+	content := &hcl.BodyContent{
+		Attributes:       hcl.Attributes{},
+		MissingItemRange: overrideContent.MissingItemRange,
+	}
+	for name, attr := range baseContent.Attributes {
+		content.Attributes[name] = attr
+	}
+	for name, attr := range overrideContent.Attributes {
+		content.Attributes[name] = attr
+	}
+
+	overriddenBlocks := map[string]bool{}
+	for _, block := range overrideContent.Blocks {
+		overriddenBlocks[block.Type] = true
+	}
+	for _, block := range baseContent.Blocks {
+		if !overriddenBlocks[block.Type] {
+			content.Blocks = append(content.Blocks, block)
+		}
+	}
+	content.Blocks = append(content.Blocks, overrideContent.Blocks...)
+
+	for _, attr := range schema.Attributes {
+		if _, exists := content.Attributes[attr.Name]; attr.Required && !exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing required argument",
+				Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", attr.Name),
+				Subject:  content.MissingItemRange.Ptr(),
+			})
+		}
+	}
+
+	if partial {
+		return content, mergeBodies(baseRemain, overrideRemain), diags
+	}
+	return content, nil, diags
+}
+
+func (b *mergedBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs := hcl.Attributes{}
+
+	baseAttrs, diags := b.base.JustAttributes()
+	for name, attr := range baseAttrs {
+		attrs[name] = attr
+	}
+	overrideAttrs, moreDiags := b.override.JustAttributes()
+	diags = diags.Extend(moreDiags)
+	for name, attr := range overrideAttrs {
+		attrs[name] = attr
+	}
+
+	return attrs, diags
+}
+
+func (b *mergedBody) MissingItemRange() hcl.Range {
+	return b.override.MissingItemRange()
+}
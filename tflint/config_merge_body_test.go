@@ -0,0 +1,91 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_mergeBodies(t *testing.T) {
+	parse := func(src string, filename string) hcl.Body {
+		file, diags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		return file.Body
+	}
+
+	base := parse(`
+foo = "base"
+bar = "base"
+
+tag "a" {}
+tag "b" {}
+item {}
+`, "base.hcl")
+	override := parse(`
+bar = "override"
+
+tag "c" {}
+`, "override.hcl")
+
+	schema := &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "foo", Required: true},
+			{Name: "bar"},
+			{Name: "baz"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "tag", LabelNames: []string{"name"}},
+			{Type: "item"},
+		},
+	}
+
+	content, diags := mergeBodies(base, override).Content(schema)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	attrs := map[string]string{}
+	for name, attr := range content.Attributes {
+		attrs[name] = attr.Range.Filename
+	}
+	if diff := cmp.Diff(map[string]string{"foo": "base.hcl", "bar": "override.hcl"}, attrs); diff != "" {
+		t.Error(diff)
+	}
+
+	blocks := []string{}
+	for _, block := range content.Blocks {
+		blocks = append(blocks, block.Type+block.DefRange.Filename)
+	}
+	// Tag blocks in the base are replaced by the override
+	if diff := cmp.Diff([]string{"itembase.hcl", "tagoverride.hcl"}, blocks); diff != "" {
+		t.Error(diff)
+	}
+
+	// Required attributes must be declared in either body
+	schema.Attributes[2].Required = true
+	_, diags = mergeBodies(base, override).Content(schema)
+	if !diags.HasErrors() || diags[0].Detail != `The argument "baz" is required, but no definition was found.` {
+		t.Errorf("expected a missing argument error, but got %s", diags)
+	}
+
+	// Unknown attributes are errors in Content, but remain in PartialContent
+	_, diags = mergeBodies(base, override).Content(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "foo"}}})
+	if !diags.HasErrors() {
+		t.Error("expected an unsupported argument error")
+	}
+	_, remain, diags := mergeBodies(base, override).PartialContent(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "foo"}}, Blocks: schema.Blocks})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	remainContent, diags := remain.Content(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "bar"}}})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if remainContent.Attributes["bar"].Range.Filename != "override.hcl" {
+		t.Errorf("expected bar in the override to remain, but got %s", remainContent.Attributes["bar"].Range)
+	}
+}
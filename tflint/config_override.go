@@ -0,0 +1,151 @@
+package tflint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"golang.org/x/exp/slices"
+)
+
+var overrideBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "files", Required: true},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "rule",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+// ConfigOverride is a set of rule configs applied only to files matching the patterns.
+type ConfigOverride struct {
+	Files []string
+	Rules map[string]*RuleConfig
+
+	// baseDir is the absolute path of the directory containing the config file.
+	// Patterns are relative to this directory.
+	baseDir string
+}
+
+func decodeOverrideBlock(block *hcl.Block, baseDir string, ctx *hcl.EvalContext) (*ConfigOverride, error) {
+	content, diags := block.Body.Content(overrideBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	override := &ConfigOverride{Rules: map[string]*RuleConfig{}, baseDir: baseDir}
+	if err := decodeConfigExpression(content.Attributes["files"].Expr, ctx, &override.Files); err != nil {
+		return nil, err
+	}
+	for _, pattern := range override.Files {
+		// Match the pattern against itself to walk all path segments
+		if _, err := doublestar.Match(pattern, pattern); err != nil {
+			return nil, fmt.Errorf("override: `%s` is an invalid file pattern; %w", pattern, err)
+		}
+	}
+
+	for _, block := range content.Blocks {
+		ruleConfig := &RuleConfig{Name: block.Labels[0]}
+		if err := gohcl.DecodeBody(block.Body, ctx, ruleConfig); err != nil {
+			return nil, err
+		}
+		if err := ruleConfig.validate(); err != nil {
+			return nil, err
+		}
+		if ruleConfig.MaxIssues != nil {
+			return nil, fmt.Errorf("override: rule `%s`: `max_issues` cannot be set in override blocks", ruleConfig.Name)
+		}
+		override.Rules[block.Labels[0]] = ruleConfig
+	}
+
+	return override, nil
+}
+
+// Match returns whether the passed file matches any of the patterns.
+// The path must be absolute. Files outside the base directory never match.
+func (o *ConfigOverride) Match(path string) bool {
+	return matchFilePatterns(o.Files, o.baseDir, path)
+}
+
+// matchFilePatterns returns whether the passed file matches any of the patterns relative to the base directory.
+// The path must be absolute. Files outside the base directory never match.
+func matchFilePatterns(patterns []string, baseDir string, path string) bool {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if matched, err := doublestar.Match(pattern, rel); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleConfigFor returns the rule config applied to the passed files, and whether any override is applied.
+// Overrides are applied only if all the files match, and later overrides take precedence.
+// The severity is inherited from the top-level rule block if the override doesn't set it.
+// The paths must be absolute.
+func (c *Config) ruleConfigFor(name string, paths ...string) (*RuleConfig, bool) {
+	ret := c.Rules[name]
+	overridden := false
+
+	for _, override := range c.Overrides {
+		rule, exists := override.Rules[name]
+		if !exists || !override.matchAll(paths) {
+			continue
+		}
+
+		merged := *rule
+		if merged.Severity == "" && ret != nil {
+			merged.Severity = ret.Severity
+		}
+		ret = &merged
+		overridden = true
+	}
+
+	return ret, overridden
+}
+
+// ruleEnabled returns whether the rule is enabled for the passed file.
+// Rules without rule blocks are considered enabled unless disabled_by_default is set,
+// as the default state of plugin rules is not known to TFLint. "all" is always enabled.
+func (c *Config) ruleEnabled(name string, path string) bool {
+	if name == "all" {
+		return true
+	}
+	rule, overridden := c.ruleConfigFor(name, path)
+	if overridden {
+		return rule.Enabled
+	}
+	if len(c.Only) > 0 {
+		return slices.Contains(c.Only, name)
+	}
+	if rule != nil {
+		return rule.Enabled
+	}
+	return !c.DisabledByDefault
+}
+
+func (o *ConfigOverride) matchAll(paths []string) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, path := range paths {
+		if !o.Match(path) {
+			return false
+		}
+	}
+	return true
+}
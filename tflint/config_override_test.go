@@ -0,0 +1,198 @@
+package tflint
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_ConfigOverride_Match(t *testing.T) {
+	base, err := filepath.Abs("project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	override := &ConfigOverride{Files: []string{"examples/**", "*.generated.tf"}, baseDir: base}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "match with **", path: filepath.Join(base, "examples", "simple", "main.tf"), want: true},
+		{name: "match with *", path: filepath.Join(base, "main.generated.tf"), want: true},
+		{name: "not match", path: filepath.Join(base, "main.tf"), want: false},
+		{name: "* doesn't match separators", path: filepath.Join(base, "modules", "main.generated.tf"), want: false},
+		{name: "outside base directory", path: filepath.Join(filepath.Dir(base), "examples", "main.tf"), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := override.Match(test.path)
+			if got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_ruleConfigFor(t *testing.T) {
+	base, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := EmptyConfig()
+	config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true, Severity: "warning"}
+	config.Overrides = []*ConfigOverride{
+		{
+			Files: []string{"examples/**"},
+			Rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: false},
+			},
+			baseDir: base,
+		},
+		{
+			Files: []string{"examples/complete/**"},
+			Rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: true, Severity: "notice"},
+			},
+			baseDir: base,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		paths          []string
+		wantEnabled    bool
+		wantSeverity   string
+		wantOverridden bool
+	}{
+		{
+			name:         "no overrides",
+			paths:        []string{"main.tf"},
+			wantEnabled:  true,
+			wantSeverity: "warning",
+		},
+		{
+			name:           "override",
+			paths:          []string{"examples/simple/main.tf"},
+			wantEnabled:    false,
+			wantSeverity:   "warning",
+			wantOverridden: true,
+		},
+		{
+			name:           "later override takes precedence",
+			paths:          []string{"examples/complete/main.tf"},
+			wantEnabled:    true,
+			wantSeverity:   "notice",
+			wantOverridden: true,
+		},
+		{
+			name:         "not all files match",
+			paths:        []string{"examples/simple/main.tf", "main.tf"},
+			wantEnabled:  true,
+			wantSeverity: "warning",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths := make([]string, len(test.paths))
+			for i, path := range test.paths {
+				paths[i] = filepath.Join(base, path)
+			}
+
+			got, overridden := config.ruleConfigFor("test_rule", paths...)
+			if got.Enabled != test.wantEnabled {
+				t.Errorf("enabled: want=%t, got=%t", test.wantEnabled, got.Enabled)
+			}
+			if got.Severity != test.wantSeverity {
+				t.Errorf("severity: want=%s, got=%s", test.wantSeverity, got.Severity)
+			}
+			if overridden != test.wantOverridden {
+				t.Errorf("overridden: want=%t, got=%t", test.wantOverridden, overridden)
+			}
+		})
+	}
+}
+
+func Test_ruleEnabled(t *testing.T) {
+	base, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides := []*ConfigOverride{
+		{
+			Files: []string{"examples/**"},
+			Rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: false},
+			},
+			baseDir: base,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		rule   string
+		path   string
+		want   bool
+	}{
+		{
+			name:   "not configured",
+			config: &Config{},
+			rule:   "test_rule",
+			path:   "main.tf",
+			want:   true,
+		},
+		{
+			name:   "disabled by default",
+			config: &Config{DisabledByDefault: true},
+			rule:   "test_rule",
+			path:   "main.tf",
+			want:   false,
+		},
+		{
+			name:   "disabled by rule block",
+			config: &Config{Rules: map[string]*RuleConfig{"test_rule": {Name: "test_rule", Enabled: false}}},
+			rule:   "test_rule",
+			path:   "main.tf",
+			want:   false,
+		},
+		{
+			name:   "not in only",
+			config: &Config{Only: []string{"other_rule"}},
+			rule:   "test_rule",
+			path:   "main.tf",
+			want:   false,
+		},
+		{
+			name:   "disabled by override",
+			config: &Config{Overrides: overrides},
+			rule:   "test_rule",
+			path:   "examples/main.tf",
+			want:   false,
+		},
+		{
+			name:   "override not matched",
+			config: &Config{Overrides: overrides},
+			rule:   "test_rule",
+			path:   "main.tf",
+			want:   true,
+		},
+		{
+			name:   "all",
+			config: &Config{DisabledByDefault: true},
+			rule:   "all",
+			path:   "main.tf",
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.config.ruleEnabled(test.rule, filepath.Join(base, test.path))
+			if got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package tflint
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint/terraform/didyoumean"
+	"golang.org/x/exp/slices"
+)
+
+// ExpandRulePatterns replaces rule patterns in the config with the names of the matched rules.
+// Patterns can be used in rule blocks, override blocks, and CLI flags such as `--only`.
+//
+// A pattern is a glob like `aws_instance_*`, optionally qualified with a ruleset name like `aws:*`.
+// Rule configs declared with the exact name take precedence over patterns from the same source,
+// and more specific (longer) patterns take precedence over less specific ones.
+// As with the exact names, patterns in CLI flags take precedence over config files.
+//
+// This must be called after launching plugins and before applying the config to them.
+// Core rules must not emit issues before that, as patterns also apply to them.
+func (c *Config) ExpandRulePatterns(rulesets ...RuleSet) error {
+	rulesMap, err := ruleRulesetMap(rulesets...)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(rulesMap))
+	for name := range rulesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	only := []string{}
+	for _, name := range c.Only {
+		matched := []string{name}
+		if isRulePattern(name) {
+			matched, err = matchRules(name, names, rulesMap)
+			if err != nil {
+				return err
+			}
+		}
+		for _, rule := range matched {
+			if !slices.Contains(only, rule) {
+				only = append(only, rule)
+			}
+		}
+	}
+	if len(c.Only) > 0 {
+		c.Only = only
+	}
+
+	if err := expandRuleConfigs(c.Rules, c.enabledByCLI, names, rulesMap); err != nil {
+		return err
+	}
+	for _, override := range c.Overrides {
+		if err := expandRuleConfigs(override.Rules, nil, names, rulesMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isRulePattern returns true if the name is a glob or qualified with a ruleset name.
+func isRulePattern(name string) bool {
+	return strings.ContainsAny(name, "*?[:")
+}
+
+// matchRules returns the names of rules that match the pattern.
+// It returns an error if no rules match, because it is likely to be a typo.
+func matchRules(pattern string, names []string, rulesMap map[string]string) ([]string, error) {
+	rulesetName, rulePattern, qualified := strings.Cut(pattern, ":")
+	if !qualified {
+		rulePattern = pattern
+	}
+
+	if qualified {
+		if rulesetName == "tag" {
+			return nil, fmt.Errorf("Rule tags are not supported: %s. Plugins do not report tags of their rules", pattern)
+		}
+
+		rulesets := []string{}
+		for _, ruleset := range rulesMap {
+			if !slices.Contains(rulesets, ruleset) {
+				rulesets = append(rulesets, ruleset)
+			}
+		}
+		sort.Strings(rulesets)
+		if !slices.Contains(rulesets, rulesetName) {
+			if suggestion := didyoumean.NameSuggestion(rulesetName, rulesets); suggestion != "" {
+				return nil, fmt.Errorf("Ruleset not found: %s. Did you mean `%s`?", rulesetName, suggestion)
+			}
+			return nil, fmt.Errorf("Ruleset not found: %s", rulesetName)
+		}
+	}
+
+	if _, err := path.Match(rulePattern, ""); err != nil {
+		return nil, fmt.Errorf("Invalid rule pattern: %s; %w", pattern, err)
+	}
+
+	matched := []string{}
+	for _, name := range names {
+		if qualified && rulesMap[name] != rulesetName {
+			continue
+		}
+		if ok, _ := path.Match(rulePattern, name); ok {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("No rules match the pattern: %s", pattern)
+	}
+	return matched, nil
+}
+
+// expandRuleConfigs replaces rule configs declared with patterns with configs of the matched rules.
+// Rule configs from files have bodies, while rule configs from CLI flags don't.
+// The enabledByCLI is the names of rules declared in files and also given in CLI flags.
+func expandRuleConfigs(rules map[string]*RuleConfig, enabledByCLI map[string]bool, names []string, rulesMap map[string]string) error {
+	filePatterns := []*RuleConfig{}
+	cliPatterns := []*RuleConfig{}
+	for name, rule := range rules {
+		if !isRulePattern(name) {
+			continue
+		}
+		if rule.Body == nil {
+			cliPatterns = append(cliPatterns, rule)
+		} else {
+			filePatterns = append(filePatterns, rule)
+		}
+		delete(rules, name)
+	}
+
+	// Exact names given in CLI flags are not overridden by any patterns
+	cliRules := map[string]bool{}
+	for name, rule := range rules {
+		if rule.Body == nil || enabledByCLI[name] {
+			cliRules[name] = true
+		}
+	}
+
+	fileMatches, err := mostSpecificPatterns(filePatterns, names, rulesMap)
+	if err != nil {
+		return err
+	}
+	for name, pattern := range fileMatches {
+		if base, exists := rules[name]; exists {
+			if base.Body != nil {
+				continue
+			}
+			// The rule is only enabled or disabled by CLI flags, so take the rest from the pattern
+			ret := *pattern
+			ret.Name = name
+			ret.Enabled = base.Enabled
+			rules[name] = &ret
+			continue
+		}
+		ret := *pattern
+		ret.Name = name
+		rules[name] = &ret
+	}
+
+	cliMatches, err := mostSpecificPatterns(cliPatterns, names, rulesMap)
+	if err != nil {
+		return err
+	}
+	for name, pattern := range cliMatches {
+		if cliRules[name] {
+			continue
+		}
+		if base, exists := rules[name]; exists {
+			ret := *base
+			ret.Enabled = pattern.Enabled
+			rules[name] = &ret
+			continue
+		}
+		rules[name] = &RuleConfig{Name: name, Enabled: pattern.Enabled}
+	}
+
+	return nil
+}
+
+// mostSpecificPatterns returns the most specific pattern that matches each rule.
+func mostSpecificPatterns(patterns []*RuleConfig, names []string, rulesMap map[string]string) (map[string]*RuleConfig, error) {
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i].Name) != len(patterns[j].Name) {
+			return len(patterns[i].Name) < len(patterns[j].Name)
+		}
+		return patterns[i].Name < patterns[j].Name
+	})
+
+	ret := map[string]*RuleConfig{}
+	for _, pattern := range patterns {
+		matched, err := matchRules(pattern.Name, names, rulesMap)
+		if err != nil {
+			return ret, err
+		}
+		for _, name := range matched {
+			ret[name] = pattern
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,188 @@
+package tflint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func TestExpandRulePatterns(t *testing.T) {
+	// Rule configs declared in files have bodies, and rule configs from CLI flags don't.
+	fileRule := func(name string, enabled bool, severity string) *RuleConfig {
+		return &RuleConfig{Name: name, Enabled: enabled, Severity: severity, Body: hcl.EmptyBody()}
+	}
+	cliConfig := func(only []string, rules ...*RuleConfig) *Config {
+		config := EmptyConfig()
+		config.Only = only
+		for _, rule := range rules {
+			config.Rules[rule.Name] = rule
+		}
+		return config
+	}
+
+	tests := []struct {
+		name      string
+		config    func() *Config
+		wantOnly  []string
+		wantRules map[string]*RuleConfig
+		wantErr   error
+	}{
+		{
+			name: "exact names",
+			config: func() *Config {
+				return cliConfig([]string{"aws_instance_invalid_type"}, &RuleConfig{Name: "aws_instance_invalid_type", Enabled: true})
+			},
+			wantOnly: []string{"aws_instance_invalid_type"},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+			},
+		},
+		{
+			name: "glob in --only",
+			config: func() *Config {
+				return cliConfig([]string{"aws_instance_*", "aws_instance_invalid_type"}, &RuleConfig{Name: "aws_instance_*", Enabled: true})
+			},
+			wantOnly: []string{"aws_instance_invalid_ami", "aws_instance_invalid_type"},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: true},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+			},
+		},
+		{
+			name: "ruleset-qualified names",
+			config: func() *Config {
+				return cliConfig(nil, &RuleConfig{Name: "ruleSetB:*", Enabled: false})
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami": {Name: "aws_instance_invalid_ami", Enabled: false},
+			},
+		},
+		{
+			name: "exact names take precedence over patterns in files",
+			config: func() *Config {
+				config := EmptyConfig()
+				config.Rules["aws_*"] = fileRule("aws_*", false, "notice")
+				config.Rules["aws_instance_invalid_type"] = fileRule("aws_instance_invalid_type", true, "")
+				return config
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: false, Severity: "notice"},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+			},
+		},
+		{
+			name: "more specific patterns take precedence",
+			config: func() *Config {
+				config := EmptyConfig()
+				config.Rules["aws_instance_*_ami"] = fileRule("aws_instance_*_ami", true, "")
+				config.Rules["aws_*"] = fileRule("aws_*", false, "")
+				return config
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: true},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: false},
+			},
+		},
+		{
+			name: "patterns in CLI flags take precedence over files",
+			config: func() *Config {
+				config := EmptyConfig()
+				config.Rules["aws_instance_invalid_type"] = fileRule("aws_instance_invalid_type", true, "warning")
+				config.Merge(cliConfig(nil, &RuleConfig{Name: "aws_*", Enabled: false}))
+				return config
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: false},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: false, Severity: "warning"},
+			},
+		},
+		{
+			name: "exact names in CLI flags take precedence over patterns in CLI flags",
+			config: func() *Config {
+				config := EmptyConfig()
+				config.Rules["aws_instance_invalid_type"] = fileRule("aws_instance_invalid_type", false, "")
+				config.Merge(cliConfig(nil,
+					&RuleConfig{Name: "aws_*", Enabled: false},
+					&RuleConfig{Name: "aws_instance_invalid_type", Enabled: true},
+				))
+				return config
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: false},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true},
+			},
+		},
+		{
+			name: "patterns in files complement exact names in CLI flags",
+			config: func() *Config {
+				config := EmptyConfig()
+				config.Rules["aws_*"] = fileRule("aws_*", false, "notice")
+				config.Merge(cliConfig(nil, &RuleConfig{Name: "aws_instance_invalid_type", Enabled: true}))
+				return config
+			},
+			wantRules: map[string]*RuleConfig{
+				"aws_instance_invalid_ami":  {Name: "aws_instance_invalid_ami", Enabled: false, Severity: "notice"},
+				"aws_instance_invalid_type": {Name: "aws_instance_invalid_type", Enabled: true, Severity: "notice"},
+			},
+		},
+		{
+			name: "no rules match",
+			config: func() *Config {
+				return cliConfig([]string{"google_*"}, &RuleConfig{Name: "google_*", Enabled: true})
+			},
+			wantErr: errors.New("No rules match the pattern: google_*"),
+		},
+		{
+			name: "ruleset not found",
+			config: func() *Config {
+				return cliConfig(nil, &RuleConfig{Name: "ruleSetC:*", Enabled: false})
+			},
+			wantErr: errors.New("Ruleset not found: ruleSetC. Did you mean `ruleSetA`?"),
+		},
+		{
+			name: "tags",
+			config: func() *Config {
+				return cliConfig([]string{"tag:security"}, &RuleConfig{Name: "tag:security", Enabled: true})
+			},
+			wantErr: errors.New("Rule tags are not supported: tag:security. Plugins do not report tags of their rules"),
+		},
+		{
+			name: "invalid pattern",
+			config: func() *Config {
+				return cliConfig(nil, &RuleConfig{Name: "aws_[", Enabled: false})
+			},
+			wantErr: errors.New("Invalid rule pattern: aws_[; syntax error in pattern"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config()
+			err := config.ExpandRulePatterns(&ruleSetA{}, &ruleSetB{})
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("expected %q, but got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.wantOnly, config.Only); diff != "" {
+				t.Error(diff)
+			}
+			opts := cmp.Options{
+				cmp.Comparer(func(x, y RuleConfig) bool {
+					return x.Name == y.Name && x.Enabled == y.Enabled && x.Severity == y.Severity
+				}),
+			}
+			// Core rules are not configured in these cases
+			if diff := cmp.Diff(test.wantRules, config.Rules, opts); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
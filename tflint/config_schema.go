@@ -0,0 +1,169 @@
+package tflint
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+// configJSONSchemaAttributes is the JSON Schema of attributes in the config block.
+// This must be kept in sync with innerConfigSchema.
+var configJSONSchemaAttributes = map[string]map[string]interface{}{
+	"module":                    {"type": "boolean", "description": "Enable module inspection"},
+	"force":                     {"type": "boolean", "description": "Return zero exit status even if issues found"},
+	"ignore_module":             {"type": "object", "additionalProperties": map[string]interface{}{"type": "boolean"}, "description": "Ignore module sources"},
+	"varfile":                   {"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Terraform variable file names"},
+	"variables":                 {"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Terraform variables in the form of foo=bar"},
+	"disabled_by_default":       {"type": "boolean", "description": "Only enable rules specifically enabled in the config and on the command line"},
+	"plugin_dir":                {"type": "string", "description": "Directory to look up plugins"},
+	"format":                    {"type": "string", "enum": validFormats, "description": "Output format"},
+	"exclude":                   {"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Files or globs to exclude from the inspection"},
+	"require_annotation_reason": {"type": "boolean", "description": "Require a reason for all annotations"},
+	"report_unused_annotations": {"type": "boolean", "description": "Report annotations that don't suppress any issues"},
+}
+
+// ConfigJSONSchema returns a JSON Schema of the config file written in the JSON syntax of HCL.
+// The rules are the names of known rules mapped to their ruleset names, and the plugin schemas
+// are the config schemas returned by plugins. They are listed as properties for completion,
+// but unknown rules and plugins are also allowed, as they may not be installed yet.
+func ConfigJSONSchema(rules map[string]string, pluginSchemas map[string]*hclext.BodySchema) map[string]interface{} {
+	stringList := map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+
+	ruleProperties := map[string]interface{}{}
+	for name, ruleset := range rules {
+		ruleProperties[name] = ruleJSONSchema(fmt.Sprintf("Rule of the %s ruleset", ruleset))
+	}
+
+	pluginProperties := map[string]interface{}{}
+	for name, schema := range pluginSchemas {
+		pluginProperties[name] = pluginJSONSchema(schema)
+	}
+
+	overrideSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"files": stringList,
+			"rule": map[string]interface{}{
+				"type":                 "object",
+				"properties":           ruleProperties,
+				"additionalProperties": ruleJSONSchema(""),
+			},
+		},
+		"required":             []string{"files"},
+		"additionalProperties": false,
+	}
+
+	ignoreSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"rule":          map[string]interface{}{"type": "string"},
+			"files":         stringList,
+			"message_regex": map[string]interface{}{"type": "string"},
+			"address":       map[string]interface{}{"type": "string"},
+			"reason":        map[string]interface{}{"type": "string"},
+			"until":         map[string]interface{}{"type": "string", "pattern": `^\d{4}-\d{2}-\d{2}$`},
+		},
+		"required":             []string{"rule"},
+		"additionalProperties": false,
+	}
+
+	// Profiles can contain any blocks except `profile` and `extends`
+	properties := map[string]interface{}{
+		"config": map[string]interface{}{
+			"type":                 "object",
+			"properties":           configJSONSchemaAttributes,
+			"additionalProperties": false,
+		},
+		"rule": map[string]interface{}{
+			"type":                 "object",
+			"properties":           ruleProperties,
+			"additionalProperties": ruleJSONSchema(""),
+		},
+		"plugin": map[string]interface{}{
+			"type":                 "object",
+			"properties":           pluginProperties,
+			"additionalProperties": pluginJSONSchema(nil),
+		},
+		"override": map[string]interface{}{
+			"anyOf": []interface{}{
+				overrideSchema,
+				map[string]interface{}{"type": "array", "items": overrideSchema},
+			},
+		},
+		"ignore": map[string]interface{}{
+			"anyOf": []interface{}{
+				ignoreSchema,
+				map[string]interface{}{"type": "array", "items": ignoreSchema},
+			},
+		},
+	}
+	profileProperties := map[string]interface{}{}
+	for name, property := range properties {
+		profileProperties[name] = property
+	}
+
+	properties["extends"] = stringList
+	properties["profile"] = map[string]interface{}{
+		"type": "object",
+		"additionalProperties": map[string]interface{}{
+			"type":                 "object",
+			"properties":           profileProperties,
+			"additionalProperties": false,
+		},
+	}
+
+	return map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "TFLint config",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// ruleJSONSchema returns a JSON Schema of rule blocks.
+// Other attributes are allowed because they are rule-specific.
+func ruleJSONSchema(description string) map[string]interface{} {
+	ret := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"enabled":    map[string]interface{}{"type": "boolean"},
+			"severity":   map[string]interface{}{"type": "string", "enum": []string{"error", "warning", "notice"}},
+			"max_issues": map[string]interface{}{"type": "integer", "minimum": 0},
+		},
+		"required": []string{"enabled"},
+	}
+	if description != "" {
+		ret["description"] = description
+	}
+	return ret
+}
+
+// pluginJSONSchema returns a JSON Schema of plugin blocks.
+// If the plugin's config schema is known, other attributes are not allowed.
+func pluginJSONSchema(schema *hclext.BodySchema) map[string]interface{} {
+	properties := map[string]interface{}{
+		"enabled":     map[string]interface{}{"type": "boolean"},
+		"version":     map[string]interface{}{"type": "string"},
+		"source":      map[string]interface{}{"type": "string"},
+		"source_type": map[string]interface{}{"type": "string", "enum": validSourceTypes},
+		"signing_key": map[string]interface{}{"type": "string"},
+	}
+	ret := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"enabled"},
+	}
+	if schema == nil {
+		return ret
+	}
+
+	for _, attr := range schema.Attributes {
+		properties[attr.Name] = map[string]interface{}{}
+	}
+	for _, block := range schema.Blocks {
+		properties[block.Type] = map[string]interface{}{}
+	}
+	ret["additionalProperties"] = false
+	return ret
+}
@@ -0,0 +1,48 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+func TestConfigJSONSchema(t *testing.T) {
+	for _, attr := range innerConfigSchema.Attributes {
+		if _, exists := configJSONSchemaAttributes[attr.Name]; !exists {
+			t.Errorf("%s is not declared in the JSON Schema", attr.Name)
+		}
+	}
+	if len(configJSONSchemaAttributes) != len(innerConfigSchema.Attributes) {
+		t.Errorf("the JSON Schema declares %d attributes, but the config schema has %d attributes", len(configJSONSchemaAttributes), len(innerConfigSchema.Attributes))
+	}
+	for _, block := range configSchema.Blocks {
+		properties := ConfigJSONSchema(map[string]string{}, map[string]*hclext.BodySchema{})["properties"].(map[string]interface{})
+		if _, exists := properties[block.Type]; !exists {
+			t.Errorf("%s block is not declared in the JSON Schema", block.Type)
+		}
+	}
+
+	schema := ConfigJSONSchema(
+		map[string]string{"aws_instance_invalid_type": "aws"},
+		map[string]*hclext.BodySchema{"aws": {Attributes: []hclext.AttributeSchema{{Name: "deep_check"}}}},
+	)
+	properties := schema["properties"].(map[string]interface{})
+
+	rules := properties["rule"].(map[string]interface{})["properties"].(map[string]interface{})
+	rule, exists := rules["aws_instance_invalid_type"]
+	if !exists {
+		t.Fatal("aws_instance_invalid_type is not declared in the JSON Schema")
+	}
+	if description := rule.(map[string]interface{})["description"]; description != "Rule of the aws ruleset" {
+		t.Errorf("unexpected description: %s", description)
+	}
+
+	plugins := properties["plugin"].(map[string]interface{})["properties"].(map[string]interface{})
+	plugin := plugins["aws"].(map[string]interface{})
+	if _, exists := plugin["properties"].(map[string]interface{})["deep_check"]; !exists {
+		t.Error("deep_check is not declared in the aws plugin")
+	}
+	if plugin["additionalProperties"] != false {
+		t.Error("additional attributes are allowed in the aws plugin")
+	}
+}
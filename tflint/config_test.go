@@ -2,7 +2,10 @@ package tflint
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -44,6 +47,11 @@ config {
 	varfile = ["example1.tfvars", "example2.tfvars"]
 
 	variables = ["foo=bar", "bar=['foo']"]
+
+	exclude = ["examples/**", "*.generated.tf"]
+
+	require_annotation_reason = true
+	report_unused_annotations = true
 }
 
 rule "aws_instance_invalid_type" {
@@ -52,6 +60,8 @@ rule "aws_instance_invalid_type" {
 
 rule "aws_instance_previous_type" {
 	enabled = false
+	severity = "warning"
+	max_issues = 10
 	foo = "bar"
 }
 
@@ -69,6 +79,15 @@ plugin "bar" {
 plugin "baz" {
 	enabled = true
 	foo = "baz"
+}
+
+override {
+	files = ["examples/**"]
+
+	rule "aws_instance_invalid_type" {
+		enabled = true
+		severity = "notice"
+	}
 }`,
 			},
 			want: &Config{
@@ -81,19 +100,29 @@ plugin "baz" {
 				},
 				Varfiles:          []string{"example1.tfvars", "example2.tfvars"},
 				Variables:         []string{"foo=bar", "bar=['foo']"},
+				Exclude:           []string{"examples/**", "*.generated.tf"},
 				DisabledByDefault: false,
 				PluginDir:         "~/.tflint.d/plugins",
 				PluginDirSet:      true,
 				Format:            "compact",
 				FormatSet:         true,
+
+				RequireAnnotationReason:    true,
+				RequireAnnotationReasonSet: true,
+
+				ReportUnusedAnnotations:    true,
+				ReportUnusedAnnotationsSet: true,
+
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
 						Name:    "aws_instance_invalid_type",
 						Enabled: false,
 					},
 					"aws_instance_previous_type": {
-						Name:    "aws_instance_previous_type",
-						Enabled: false,
+						Name:      "aws_instance_previous_type",
+						Enabled:   false,
+						Severity:  "warning",
+						MaxIssues: func() *int { n := 10; return &n }(),
 					},
 				},
 				Plugins: map[string]*PluginConfig{
@@ -119,6 +148,18 @@ plugin "baz" {
 						Enabled: true,
 					},
 				},
+				Overrides: []*ConfigOverride{
+					{
+						Files: []string{"examples/**"},
+						Rules: map[string]*RuleConfig{
+							"aws_instance_invalid_type": {
+								Name:     "aws_instance_invalid_type",
+								Enabled:  true,
+								Severity: "notice",
+							},
+						},
+					},
+				},
 			},
 			errCheck: neverHappend,
 		},
@@ -158,6 +199,73 @@ config {
 			},
 			errCheck: neverHappend,
 		},
+		{
+			name: "default JSON config",
+			file: ".tflint.hcl",
+			files: map[string]string{
+				".tflint.json": `{
+	"config": {
+		"force": true,
+		"varfile": ["example1.tfvars"]
+	},
+	"rule": {
+		"aws_instance_invalid_type": {
+			"enabled": false,
+			"severity": "warning"
+		}
+	}
+}`,
+				"/root/.tflint.hcl": `
+config {
+	disabled_by_default = true
+}`,
+			},
+			want: &Config{
+				Module:        false,
+				Force:         true,
+				ForceSet:      true,
+				IgnoreModules: map[string]bool{},
+				Varfiles:      []string{"example1.tfvars"},
+				Variables:     []string{},
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  false,
+						Severity: "warning",
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "default home JSON config",
+			file: ".tflint.hcl",
+			files: map[string]string{
+				"/root/.tflint.json": `{"config": {"force": true}}`,
+			},
+			want: &Config{
+				Module:        false,
+				Force:         true,
+				ForceSet:      true,
+				IgnoreModules: map[string]bool{},
+				Varfiles:      []string{},
+				Variables:     []string{},
+				Rules:         map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"terraform": {
+						Name:    "terraform",
+						Enabled: true,
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
 		{
 			name:     "no config",
 			file:     ".tflint.hcl",
@@ -280,19 +388,95 @@ plugin "foo" {
 			},
 		},
 		{
-			name: "plugin with invalid source host",
-			file: "plugin_with_invalid_source_host.hcl",
+			name: "plugin with invalid source type",
+			file: "plugin_with_invalid_source_type.hcl",
 			files: map[string]string{
-				"plugin_with_invalid_source_host.hcl": `
+				"plugin_with_invalid_source_type.hcl": `
+plugin "foo" {
+	enabled = true
+
+	version     = "0.1.0"
+	source      = "git.example.com/foo/bar"
+	source_type = "gitea"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "plugin `foo`: `source_type` is invalid. Allowed values are: github, gitlab, http"
+			},
+		},
+		{
+			name: "plugin with invalid GitLab source",
+			file: "plugin_with_invalid_gitlab_source.hcl",
+			files: map[string]string{
+				"plugin_with_invalid_gitlab_source.hcl": `
 plugin "foo" {
 	enabled = true
 
 	version = "0.1.0"
-	source = "gitlab.com/foo/bar"
+	source  = "gitlab.com/foo"
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != "plugin `foo`: `source` is invalid. Hostname must be `github.com`"
+				return err == nil || err.Error() != "plugin `foo`: `source` is invalid. Must be in the format `gitlab.com/namespace/project`"
+			},
+		},
+		{
+			name: "rule with invalid severity",
+			file: "rule_with_invalid_severity.hcl",
+			files: map[string]string{
+				"rule_with_invalid_severity.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = true
+	severity = "critical"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "rule `aws_instance_invalid_type`: `severity` is invalid. Allowed values are: error, warning, notice"
+			},
+		},
+		{
+			name: "rule with negative max_issues",
+			file: "rule_with_negative_max_issues.hcl",
+			files: map[string]string{
+				"rule_with_negative_max_issues.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = true
+	max_issues = -1
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "rule `aws_instance_invalid_type`: `max_issues` must be zero or more"
+			},
+		},
+		{
+			name: "override with max_issues",
+			file: "override_with_max_issues.hcl",
+			files: map[string]string{
+				"override_with_max_issues.hcl": `
+override {
+	files = ["examples/**"]
+
+	rule "aws_instance_invalid_type" {
+		enabled = true
+		max_issues = 10
+	}
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "override: rule `aws_instance_invalid_type`: `max_issues` cannot be set in override blocks"
+			},
+		},
+		{
+			name: "override with invalid pattern",
+			file: "override_with_invalid_pattern.hcl",
+			files: map[string]string{
+				"override_with_invalid_pattern.hcl": `
+override {
+	files = ["examples/[a-"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "override: `examples/[a-` is an invalid file pattern; syntax error in pattern"
 			},
 		},
 	}
@@ -313,7 +497,7 @@ plugin "foo" {
 			}
 
 			opts := []cmp.Option{
-				cmpopts.IgnoreUnexported(Config{}),
+				cmpopts.IgnoreUnexported(Config{}, ConfigOverride{}),
 				cmpopts.IgnoreFields(PluginConfig{}, "Body"),
 				cmpopts.IgnoreFields(RuleConfig{}, "Body"),
 			}
@@ -324,6 +508,475 @@ plugin "foo" {
 	}
 }
 
+func TestLoadConfig_extends(t *testing.T) {
+	files := map[string]string{
+		"policy/base.hcl": `
+config {
+	force = true
+	varfile = ["base.tfvars"]
+	exclude = ["generated/"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = true
+	severity = "warning"
+	foo = "base"
+	bar = "base"
+}
+
+plugin "aws" {
+	enabled = true
+	version = "0.1.0"
+	source = "github.com/terraform-linters/tflint-ruleset-aws"
+
+	deep_check = false
+	region = "us-east-1"
+}`,
+		"repo/.tflint.hcl": `
+extends = ["../policy/base.hcl"]
+
+config {
+	format = "compact"
+	varfile = ["repo.tfvars"]
+	exclude = ["legacy/"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = true
+	bar = "repo"
+}
+
+plugin "aws" {
+	enabled = true
+	deep_check = true
+}`,
+		"repo/extra.hcl": `
+config {
+	format = "json"
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = false
+}`,
+		"circular/a.hcl": `extends = ["b.hcl"]`,
+		"circular/b.hcl": `extends = ["a.hcl"]`,
+		"missing.hcl":    `extends = ["not_found.hcl"]`,
+	}
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	for name, src := range files {
+		if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	attrValues := func(body hcl.Body) map[string]string {
+		attrs, diags := body.JustAttributes()
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		ret := map[string]string{}
+		for name, attr := range attrs {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			ret[name] = val.GoString()
+		}
+		return ret
+	}
+
+	t.Run("extends", func(t *testing.T) {
+		config, err := LoadConfig(fs, "repo/.tflint.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !config.Force || config.Format != "compact" {
+			t.Errorf("expected force and format to be merged, but got force=%t, format=%s", config.Force, config.Format)
+		}
+		if diff := cmp.Diff([]string{"base.tfvars", "repo.tfvars"}, config.Varfiles); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff([]string{"generated/", "legacy/"}, config.Exclude); diff != "" {
+			t.Error(diff)
+		}
+		policyDir, err := filepath.Abs("policy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		repoDir, err := filepath.Abs("repo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{policyDir, repoDir}, config.ExcludeBaseDirs()); diff != "" {
+			t.Error(diff)
+		}
+
+		rule := config.Rules["aws_instance_invalid_type"]
+		if rule.Severity != "warning" {
+			t.Errorf("expected the severity to be inherited, but got %s", rule.Severity)
+		}
+		want := map[string]string{"foo": `cty.StringVal("base")`, "bar": `cty.StringVal("repo")`}
+		if diff := cmp.Diff(want, attrValues(rule.Body)); diff != "" {
+			t.Error(diff)
+		}
+
+		plugin := config.Plugins["aws"]
+		if plugin.Version != "0.1.0" || plugin.SourceOwner != "terraform-linters" {
+			t.Errorf("expected the source to be inherited, but got version=%s, owner=%s", plugin.Version, plugin.SourceOwner)
+		}
+		want = map[string]string{"deep_check": "cty.True", "region": `cty.StringVal("us-east-1")`}
+		if diff := cmp.Diff(want, attrValues(plugin.Body)); diff != "" {
+			t.Error(diff)
+		}
+
+		sources := config.Sources()
+		for _, name := range []string{"policy/base.hcl", "repo/.tflint.hcl"} {
+			if _, exists := sources[name]; !exists {
+				t.Errorf("expected %s in sources", name)
+			}
+		}
+	})
+
+	t.Run("multiple files", func(t *testing.T) {
+		config, err := LoadConfig(fs, "repo/.tflint.hcl", "repo/extra.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.Format != "json" {
+			t.Errorf("expected the later file to take precedence, but got format=%s", config.Format)
+		}
+		rule := config.Rules["aws_instance_invalid_type"]
+		if rule.Enabled || rule.Severity != "warning" {
+			t.Errorf("expected the rule to be disabled with the inherited severity, but got enabled=%t, severity=%s", rule.Enabled, rule.Severity)
+		}
+		if _, exists := config.Sources()["repo/extra.hcl"]; !exists {
+			t.Error("expected repo/extra.hcl in sources")
+		}
+	})
+
+	t.Run("circular", func(t *testing.T) {
+		_, err := LoadConfig(fs, "circular/a.hcl")
+		if err == nil || err.Error() != "`circular/a.hcl` is extended circularly from `circular/b.hcl`" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := LoadConfig(fs, "missing.hcl")
+		if err == nil || err.Error() != "failed to load `not_found.hcl` extended from `missing.hcl`: open not_found.hcl: file does not exist" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("multiple files with not found", func(t *testing.T) {
+		_, err := LoadConfig(fs, "repo/.tflint.hcl", "not_found.hcl")
+		if err == nil || err.Error() != "failed to load file: open not_found.hcl: file does not exist" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoadConfig_profiles(t *testing.T) {
+	files := map[string]string{
+		"policy/base.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = false
+}
+
+profile "ci" {
+	config {
+		force = false
+	}
+
+	rule "aws_instance_invalid_type" {
+		enabled = true
+	}
+}`,
+		".tflint.hcl": `
+extends = ["policy/base.hcl"]
+
+config {
+	force = true
+	format = "compact"
+}
+
+profile "ci" {
+	config {
+		format = "sarif"
+	}
+}
+
+profile "local" {
+	plugin "aws" {
+		enabled = false
+	}
+}`,
+		"empty.hcl": `
+config {
+	force = true
+}`,
+		"duplicate.hcl": `
+profile "ci" {}
+profile "ci" {}`,
+		"invalid.hcl": `
+profile "ci" {
+	extends = ["policy/base.hcl"]
+}`,
+	}
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	for name, src := range files {
+		if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("without profile", func(t *testing.T) {
+		config, err := LoadConfig(fs, ".tflint.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !config.Force || config.Format != "compact" {
+			t.Errorf("expected profiles not to be applied, but got force=%t, format=%s", config.Force, config.Format)
+		}
+		if config.Rules["aws_instance_invalid_type"].Enabled {
+			t.Error("expected the rule to be disabled")
+		}
+		if diff := cmp.Diff([]string{"ci", "local"}, config.ProfileNames()); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("apply profile", func(t *testing.T) {
+		config, err := LoadConfig(fs, ".tflint.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := config.ApplyProfile("ci"); err != nil {
+			t.Fatal(err)
+		}
+
+		if config.Force || config.Format != "sarif" {
+			t.Errorf("expected profiles in all files to be applied, but got force=%t, format=%s", config.Force, config.Format)
+		}
+		if !config.Rules["aws_instance_invalid_type"].Enabled {
+			t.Error("expected the rule to be enabled")
+		}
+		if _, exists := config.Plugins["aws"]; exists {
+			t.Error("expected the other profile not to be applied")
+		}
+	})
+
+	t.Run("profile not declared", func(t *testing.T) {
+		config, err := LoadConfig(fs, ".tflint.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = config.ApplyProfile("cl")
+		if err == nil || err.Error() != "profile `cl` is not declared. Did you mean `ci`?" {
+			t.Errorf("unexpected error: %v", err)
+		}
+		err = config.ApplyProfile("production")
+		if err == nil || err.Error() != "profile `production` is not declared. Available profiles are: ci, local" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("no profiles", func(t *testing.T) {
+		config, err := LoadConfig(fs, "empty.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = config.ApplyProfile("ci")
+		if err == nil || err.Error() != "profile `ci` is not declared. No profiles are declared in config files" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := LoadConfig(fs, "duplicate.hcl")
+		if err == nil || err.Error() != "profile `ci` is declared more than once in duplicate.hcl" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("extends in profile", func(t *testing.T) {
+		_, err := LoadConfig(fs, "invalid.hcl")
+		if err == nil || !strings.Contains(err.Error(), `An argument named "extends" is not expected here.`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoadConfig_pluginSources(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		sourceType string
+		wantType   string
+		wantOwner  string
+		wantRepo   string
+	}{
+		{
+			name:      "GitHub",
+			source:    "github.com/foo/tflint-ruleset-bar",
+			wantType:  "github",
+			wantOwner: "foo",
+			wantRepo:  "tflint-ruleset-bar",
+		},
+		{
+			name:      "GitHub Enterprise Server",
+			source:    "ghe.example.com/foo/tflint-ruleset-bar",
+			wantType:  "github",
+			wantOwner: "foo",
+			wantRepo:  "tflint-ruleset-bar",
+		},
+		{
+			name:      "GitLab",
+			source:    "gitlab.com/foo/tools/tflint-ruleset-bar",
+			wantType:  "gitlab",
+			wantOwner: "foo/tools",
+			wantRepo:  "tflint-ruleset-bar",
+		},
+		{
+			name:       "self-managed GitLab",
+			source:     "gitlab.example.com/foo/tflint-ruleset-bar",
+			sourceType: "gitlab",
+			wantType:   "gitlab",
+			wantOwner:  "foo",
+			wantRepo:   "tflint-ruleset-bar",
+		},
+		{
+			name:       "HTTP",
+			source:     "plugins.example.com/tflint-ruleset-bar",
+			sourceType: "http",
+			wantType:   "http",
+			wantOwner:  "",
+			wantRepo:   "tflint-ruleset-bar",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			content := fmt.Sprintf(`
+plugin "bar" {
+  enabled     = true
+  version     = "0.1.0"
+  source      = "%s"
+  source_type = "%s"
+}`, test.source, test.sourceType)
+			if err := fs.WriteFile(".tflint.hcl", []byte(content), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(fs, ".tflint.hcl")
+			if err != nil {
+				t.Fatal(err)
+			}
+			plugin := config.Plugins["bar"]
+
+			if got := plugin.EffectiveSourceType(); got != test.wantType {
+				t.Errorf("expected source type is %s, but got %s", test.wantType, got)
+			}
+			if plugin.SourceOwner != test.wantOwner || plugin.SourceRepo != test.wantRepo {
+				t.Errorf("expected owner=%s, repo=%s, but got owner=%s, repo=%s", test.wantOwner, test.wantRepo, plugin.SourceOwner, plugin.SourceRepo)
+			}
+		})
+	}
+}
+
+func TestFindConfigFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		dir   string
+		root  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "nearest last",
+			dir:   "/repo/modules/app",
+			files: []string{"/repo/.git/HEAD", "/repo/.tflint.hcl", "/repo/modules/app/.tflint.hcl"},
+			want:  []string{"/repo/.tflint.hcl", "/repo/modules/app/.tflint.hcl"},
+		},
+		{
+			name:  "stop at repository root",
+			dir:   "/repo/modules/app",
+			files: []string{"/.tflint.hcl", "/repo/.git", "/repo/modules/.tflint.hcl"},
+			want:  []string{"/repo/modules/.tflint.hcl"},
+		},
+		{
+			name:  "without repository",
+			dir:   "/repo/modules/app",
+			root:  "/repo",
+			files: []string{"/.tflint.hcl", "/repo/.tflint.hcl", "/repo/modules/.tflint.hcl"},
+			want:  []string{"/repo/.tflint.hcl", "/repo/modules/.tflint.hcl"},
+		},
+		{
+			name:  "repository outside the root",
+			dir:   "/repo/modules/app",
+			root:  "/repo/modules",
+			files: []string{"/repo/.git", "/repo/.tflint.hcl", "/repo/modules/.tflint.hcl"},
+			want:  []string{"/repo/.tflint.hcl", "/repo/modules/.tflint.hcl"},
+		},
+		{
+			name:  "JSON syntax",
+			dir:   "/repo/modules/app",
+			files: []string{"/repo/.git/HEAD", "/repo/.tflint.json", "/repo/modules/app/.tflint.hcl"},
+			want:  []string{"/repo/.tflint.json", "/repo/modules/app/.tflint.hcl"},
+		},
+		{
+			name:  "native syntax takes precedence",
+			dir:   "/repo",
+			files: []string{"/repo/.git/HEAD", "/repo/.tflint.hcl", "/repo/.tflint.json"},
+			want:  []string{"/repo/.tflint.hcl"},
+		},
+		{
+			name:  "no config",
+			dir:   "/repo/modules/app",
+			files: []string{"/repo/.git/HEAD"},
+			want:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			if err := fs.MkdirAll(test.dir, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range test.files {
+				if err := fs.WriteFile(file, []byte{}, os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			root := test.root
+			if root == "" {
+				root = "/"
+			}
+			got, err := FindConfigFiles(fs, test.dir, root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make([]string, len(test.want))
+			for i, path := range test.want {
+				want[i] = filepath.FromSlash(path)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestMerge(t *testing.T) {
 	file1, diags := hclsyntax.ParseConfig([]byte(`foo = "bar"`), "test.hcl", hcl.Pos{})
 	if diags.HasErrors() {
@@ -401,6 +1054,7 @@ func TestMerge(t *testing.T) {
 				},
 				Varfiles:             []string{"example1.tfvars", "example2.tfvars"},
 				Variables:            []string{"foo=bar"},
+				Exclude:              []string{"examples/**"},
 				DisabledByDefault:    true,
 				DisabledByDefaultSet: true,
 				PluginDir:            "./.tflint.d/plugins",
@@ -440,6 +1094,7 @@ func TestMerge(t *testing.T) {
 				},
 				Varfiles:             []string{"example3.tfvars"},
 				Variables:            []string{"bar=baz"},
+				Exclude:              []string{"*.generated.tf"},
 				DisabledByDefault:    false,
 				DisabledByDefaultSet: true,
 				PluginDir:            "~/.tflint.d/plugins",
@@ -481,6 +1136,7 @@ func TestMerge(t *testing.T) {
 				},
 				Varfiles:             []string{"example1.tfvars", "example2.tfvars", "example3.tfvars"},
 				Variables:            []string{"foo=bar", "bar=baz"},
+				Exclude:              []string{"examples/**", "*.generated.tf"},
 				DisabledByDefault:    false,
 				DisabledByDefaultSet: true,
 				PluginDir:            "~/.tflint.d/plugins",
@@ -496,7 +1152,7 @@ func TestMerge(t *testing.T) {
 					"aws_instance_invalid_ami": {
 						Name:    "aws_instance_invalid_ami",
 						Enabled: false,
-						Body:    file2.Body,
+						Body:    &mergedBody{base: file1.Body, override: file2.Body},
 					},
 					"aws_instance_previous_type": {
 						Name:    "aws_instance_previous_type",
@@ -759,6 +1415,7 @@ func TestMerge(t *testing.T) {
 
 			opts := []cmp.Option{
 				cmpopts.IgnoreUnexported(Config{}),
+				cmp.AllowUnexported(mergedBody{}),
 				cmpopts.IgnoreUnexported(hclsyntax.Body{}),
 				cmpopts.IgnoreFields(hclsyntax.Body{}, "Attributes", "Blocks"),
 			}
@@ -1029,6 +1686,32 @@ func Test_ValidateRules(t *testing.T) {
 			RuleSets: []RuleSet{&ruleSetB{}},
 			Err:      errors.New("Rule not found: aws_instance_invalid_type"),
 		},
+		{
+			Name: "not found with a suggestion",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_amy": {
+						Name:    "aws_instance_invalid_amy",
+						Enabled: true,
+					},
+				},
+			},
+			RuleSets: []RuleSet{&ruleSetA{}, &ruleSetB{}},
+			Err:      errors.New("Rule not found: aws_instance_invalid_amy. Did you mean `aws_instance_invalid_ami`?"),
+		},
+		{
+			Name: "core rule",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{
+					"tflint_provider_lock_mismatch": {
+						Name:    "tflint_provider_lock_mismatch",
+						Enabled: false,
+					},
+				},
+			},
+			RuleSets: []RuleSet{},
+			Err:      nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1047,4 +1730,12 @@ func Test_ValidateRules(t *testing.T) {
 			}
 		}
 	}
+	config = &Config{}
+	if err := config.ValidateRules(&ruleSetA{}, &ruleSetB{}); err != nil {
+		t.Fatal(err)
+	}
+	rulesets := config.RuleRulesets()
+	if rulesets["aws_instance_invalid_type"] != "ruleSetA" || rulesets["aws_instance_invalid_ami"] != "ruleSetB" || rulesets["tflint_provider_lock_mismatch"] != "core" {
+		t.Fatalf("unexpected rulesets: %v", rulesets)
+	}
 }
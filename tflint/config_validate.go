@@ -0,0 +1,147 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint/terraform/didyoumean"
+	"golang.org/x/exp/slices"
+)
+
+// ruleConfigAttributes is the attributes of rule blocks interpreted by TFLint itself.
+// Other attributes are passed to the rule.
+var ruleConfigAttributes = []string{"enabled", "severity", "max_issues"}
+
+// ValidateRuleBodies reports attributes and blocks in rule blocks that are unlikely to be accepted.
+// Plugins do not share rule config schemas with TFLint, so rule blocks of plugins are only checked
+// for misspellings of the attributes interpreted by TFLint, and they are reported as warnings.
+// Core rules do not accept any other attributes, so they are reported as errors.
+//
+// Rules of plugins validate their own attributes when they are run.
+// This must be called after ValidateRules.
+func (c *Config) ValidateRuleBodies() hcl.Diagnostics {
+	diags := hcl.Diagnostics{}
+
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diags = diags.Extend(c.validateRuleBody(c.Rules[name]))
+	}
+
+	for _, override := range c.Overrides {
+		names := make([]string, 0, len(override.Rules))
+		for name := range override.Rules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			diags = diags.Extend(c.validateRuleBody(override.Rules[name]))
+		}
+	}
+
+	return diags
+}
+
+func (c *Config) validateRuleBody(rule *RuleConfig) hcl.Diagnostics {
+	diags := hcl.Diagnostics{}
+	if rule.Body == nil {
+		return diags
+	}
+	core := c.ruleRulesets[rule.Name] == "core"
+
+	attrs, blocks := bodyItems(rule.Body)
+	for _, attr := range attrs {
+		if slices.Contains(ruleConfigAttributes, attr.Name) {
+			continue
+		}
+
+		if core {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported argument",
+				Detail:   fmt.Sprintf("The rule %q does not accept an argument named %q.%s", rule.Name, attr.Name, didYouMean(attr.Name, ruleConfigAttributes)),
+				Subject:  attr.NameRange.Ptr(),
+			})
+			continue
+		}
+		if suggestion := didyoumean.NameSuggestion(attr.Name, ruleConfigAttributes); suggestion != "" {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Possibly misspelled argument",
+				Detail:   fmt.Sprintf("An argument named %q is passed to the rule %q as a rule config. Did you mean %q?", attr.Name, rule.Name, suggestion),
+				Subject:  attr.NameRange.Ptr(),
+			})
+		}
+	}
+
+	if core {
+		for _, block := range blocks {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported block type",
+				Detail:   fmt.Sprintf("The rule %q does not accept blocks of type %q.", rule.Name, block.Type),
+				Subject:  block.TypeRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// bodyItems returns attributes and blocks in the body without a schema, sorted by position.
+// Bodies other than the native syntax cannot distinguish blocks from attributes,
+// so all items are returned as attributes.
+func bodyItems(body hcl.Body) ([]*hcl.Attribute, []*hcl.Block) {
+	attrs := map[string]*hcl.Attribute{}
+	blocks := []*hcl.Block{}
+
+	switch body := body.(type) {
+	case *hclsyntax.Body:
+		for _, attr := range body.Attributes {
+			attrs[attr.Name] = attr.AsHCLAttribute()
+		}
+		for _, block := range body.Blocks {
+			blocks = append(blocks, block.AsHCLBlock())
+		}
+	case *mergedBody:
+		baseAttrs, baseBlocks := bodyItems(body.base)
+		overrideAttrs, overrideBlocks := bodyItems(body.override)
+		for _, attr := range baseAttrs {
+			attrs[attr.Name] = attr
+		}
+		for _, attr := range overrideAttrs {
+			attrs[attr.Name] = attr
+		}
+		blocks = append(blocks, baseBlocks...)
+		blocks = append(blocks, overrideBlocks...)
+	default:
+		justAttrs, _ := body.JustAttributes()
+		for name, attr := range justAttrs {
+			attrs[name] = attr
+		}
+	}
+
+	ret := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		ret = append(ret, attr)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Range.Filename != ret[j].Range.Filename {
+			return ret[i].Range.Filename < ret[j].Range.Filename
+		}
+		return ret[i].Range.Start.Byte < ret[j].Range.Start.Byte
+	})
+	return ret, blocks
+}
+
+func didYouMean(given string, suggestions []string) string {
+	if suggestion := didyoumean.NameSuggestion(given, suggestions); suggestion != "" {
+		return fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package tflint
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func Test_ValidateRuleBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs map[string]string
+		want    []string
+	}{
+		{
+			name: "valid",
+			configs: map[string]string{
+				".tflint.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "warning"
+  types    = ["t2.micro"]
+}
+
+rule "tflint_unused_annotation" {
+  enabled = false
+}`,
+			},
+			want: []string{},
+		},
+		{
+			name: "misspelled attributes in plugin rules",
+			configs: map[string]string{
+				".tflint.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled = true
+  severty = "warning"
+}`,
+			},
+			want: []string{
+				`warning: .tflint.hcl:4,3-10: Possibly misspelled argument; An argument named "severty" is passed to the rule "aws_instance_invalid_type" as a rule config. Did you mean "severity"?`,
+			},
+		},
+		{
+			name: "unsupported items in core rules",
+			configs: map[string]string{
+				".tflint.hcl": `
+rule "tflint_unused_annotation" {
+  enabled    = true
+  max_issue  = 1
+  format     = "foo"
+
+  nested {}
+}`,
+			},
+			want: []string{
+				`error: .tflint.hcl:4,3-12: Unsupported argument; The rule "tflint_unused_annotation" does not accept an argument named "max_issue". Did you mean "max_issues"?`,
+				`error: .tflint.hcl:5,3-9: Unsupported argument; The rule "tflint_unused_annotation" does not accept an argument named "format".`,
+				`error: .tflint.hcl:7,3-9: Unsupported block type; The rule "tflint_unused_annotation" does not accept blocks of type "nested".`,
+			},
+		},
+		{
+			name: "override blocks",
+			configs: map[string]string{
+				".tflint.hcl": `
+override {
+  files = ["*.tf"]
+
+  rule "aws_instance_invalid_type" {
+    enabled = false
+    enable  = true
+  }
+}`,
+			},
+			want: []string{
+				`warning: .tflint.hcl:7,5-11: Possibly misspelled argument; An argument named "enable" is passed to the rule "aws_instance_invalid_type" as a rule config. Did you mean "enabled"?`,
+			},
+		},
+		{
+			name: "merged configs",
+			configs: map[string]string{
+				"base.hcl": `
+rule "tflint_unused_annotation" {
+  enabled = true
+  foo     = 1
+}`,
+				".tflint.hcl": `
+extends = ["base.hcl"]
+
+rule "tflint_unused_annotation" {
+  enabled = false
+}`,
+			},
+			want: []string{
+				`error: base.hcl:4,3-6: Unsupported argument; The rule "tflint_unused_annotation" does not accept an argument named "foo".`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			for name, src := range test.configs {
+				if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			config, err := LoadConfig(fs, ".tflint.hcl")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := config.ValidateRules(&ruleSetA{}); err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, diag := range config.ValidateRuleBodies() {
+				severity := "error"
+				if diag.Severity == hcl.DiagWarning {
+					severity = "warning"
+				}
+				got = append(got, severity+": "+diag.Subject.String()+": "+diag.Summary+"; "+diag.Detail)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
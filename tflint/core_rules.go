@@ -0,0 +1,99 @@
+package tflint
+
+import (
+	"fmt"
+
+	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"golang.org/x/exp/slices"
+)
+
+// coreRule is a rule built into TFLint itself, not provided by plugins.
+// Core rules report problems that only the host can detect, and they can be
+// enabled/disabled with rule blocks and annotations like plugin rules.
+type coreRule struct {
+	name     string
+	severity Severity
+	doc      string
+}
+
+var _ Rule = (*coreRule)(nil)
+
+// Name returns the rule name
+func (r *coreRule) Name() string {
+	return r.name
+}
+
+// Severity returns the rule severity
+func (r *coreRule) Severity() Severity {
+	return r.severity
+}
+
+// Link returns the rule reference link
+func (r *coreRule) Link() string {
+	return fmt.Sprintf("https://github.com/terraform-linters/tflint/blob/v%s/docs/user-guide/%s", Version, r.doc)
+}
+
+var providerLockMismatchRule = &coreRule{
+	name:     "tflint_provider_lock_mismatch",
+	severity: sdk.WARNING,
+	doc:      "compatibility.md#dependency-lock-file",
+}
+
+var expiredAnnotationRule = &coreRule{
+	name:     "tflint_expired_annotation",
+	severity: sdk.WARNING,
+	doc:      "annotations.md#reasons-and-expiry-dates",
+}
+
+var invalidAnnotationOptionRule = &coreRule{
+	name:     "tflint_invalid_annotation_option",
+	severity: sdk.WARNING,
+	doc:      "annotations.md#reasons-and-expiry-dates",
+}
+
+var annotationReasonRule = &coreRule{
+	name:     "tflint_annotation_reason_required",
+	severity: sdk.ERROR,
+	doc:      "annotations.md#reasons-and-expiry-dates",
+}
+
+var unusedAnnotationRule = &coreRule{
+	name:     "tflint_unused_annotation",
+	severity: sdk.WARNING,
+	doc:      "annotations.md#unused-annotations",
+}
+
+var unknownAnnotationRuleRule = &coreRule{
+	name:     "tflint_unknown_annotation_rule",
+	severity: sdk.WARNING,
+	doc:      "annotations.md#unused-annotations",
+}
+
+var unusedBaselineEntryRule = &coreRule{
+	name:     "tflint_unused_baseline_entry",
+	severity: sdk.WARNING,
+	doc:      "baseline.md#pruning-the-baseline",
+}
+
+var coreRules = []*coreRule{
+	providerLockMismatchRule,
+	expiredAnnotationRule,
+	invalidAnnotationOptionRule,
+	annotationReasonRule,
+	unusedAnnotationRule,
+	unknownAnnotationRuleRule,
+	unusedBaselineEntryRule,
+}
+
+// coreRuleEnabled returns whether the given core rule is enabled.
+// The priority is the same as plugin rules; --only option, rule blocks, and
+// the `disabled_by_default` attribute.
+func (c *Config) coreRuleEnabled(rule *coreRule) bool {
+	if len(c.Only) > 0 {
+		return slices.Contains(c.Only, rule.name)
+	}
+	if cfg, exists := c.Rules[rule.name]; exists {
+		return cfg.Enabled
+	}
+	return !c.DisabledByDefault
+}
@@ -0,0 +1,111 @@
+package tflint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint/terraform/addrs"
+)
+
+// Fingerprint returns an identifier of the issue that is stable across unrelated changes.
+//
+// The fingerprint is calculated from the rule name, and the file names, the addresses of
+// the enclosing blocks, and the normalized source snippets of the issue and its callers.
+// Line numbers are not included, so adding or removing other lines does not change it.
+// The passed files are used to look up blocks and sources. They are usually all files
+// loaded by the loader, including module files.
+func Fingerprint(issue *Issue, files map[string]*hcl.File) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n", issue.Rule.Name())
+
+	ranges := append([]hcl.Range{issue.Range}, issue.Callers...)
+	for _, rng := range ranges {
+		file := files[rng.Filename]
+		fmt.Fprintf(hash, "%s\n%s\n%s\n", filepath.ToSlash(rng.Filename), blockAddress(file, rng), snippet(file, rng))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// Address returns the address of the object enclosing the issue, like "aws_instance.web",
+// "data.aws_ami.main", and "module.app[\"a\"].aws_instance.web".
+// The passed module is the instance of the module where the issue was found.
+// Issues in child modules are located by the last caller.
+// It returns an empty string if the issue is not in a resource, data, or module block.
+//
+// Instance keys of resources are not included, because an issue is reported once
+// for an expression shared by all instances of the resource.
+func Address(issue *Issue, module addrs.ModuleInstance, files map[string]*hcl.File) string {
+	rng := issue.Range
+	if len(issue.Callers) > 0 {
+		rng = issue.Callers[len(issue.Callers)-1]
+	}
+
+	block := enclosingBlock(files[rng.Filename], rng)
+	if block == nil {
+		return ""
+	}
+	var addr string
+	switch block.Type {
+	case "resource":
+		addr = strings.Join(block.Labels, ".")
+	case "data", "module":
+		addr = strings.Join(append([]string{block.Type}, block.Labels...), ".")
+	default:
+		return ""
+	}
+
+	if module.IsRoot() {
+		return addr
+	}
+	return module.String() + "." + addr
+}
+
+// blockAddress returns the address of the top-level block containing the passed range,
+// like "aws_instance.main", "data.aws_ami.main", and "module.vpc".
+// It returns an empty string if the block is not found, or the file is not written in native syntax.
+func blockAddress(file *hcl.File, rng hcl.Range) string {
+	block := enclosingBlock(file, rng)
+	if block == nil {
+		return ""
+	}
+
+	switch block.Type {
+	case "resource":
+		return strings.Join(block.Labels, ".")
+	default:
+		return strings.Join(append([]string{block.Type}, block.Labels...), ".")
+	}
+}
+
+// enclosingBlock returns the top-level block containing the passed range.
+// It returns nil if the block is not found, or the file is not written in native syntax.
+func enclosingBlock(file *hcl.File, rng hcl.Range) *hclsyntax.Block {
+	if file == nil {
+		return nil
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+
+	for _, block := range body.Blocks {
+		if block.Range().ContainsOffset(rng.Start.Byte) {
+			return block
+		}
+	}
+	return nil
+}
+
+// snippet returns the source code of the passed range with whitespaces normalized.
+func snippet(file *hcl.File, rng hcl.Range) string {
+	if file == nil || rng.Empty() || rng.End.Byte > len(file.Bytes) {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(rng.SliceBytes(file.Bytes))), " ")
+}
@@ -0,0 +1,209 @@
+package tflint
+
+import (
+	"strings"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint/terraform/addrs"
+)
+
+// testRangeOf returns the range of the nth occurrence (0-based) of the passed substring
+func testRangeOf(t *testing.T, file *hcl.File, substr string, nth int) hcl.Range {
+	offset := 0
+	for i := 0; ; i++ {
+		idx := strings.Index(string(file.Bytes[offset:]), substr)
+		if idx < 0 {
+			t.Fatalf("%q is not found in the source", substr)
+		}
+		if i == nth {
+			start := offset + idx
+			return hcl.Range{
+				Filename: "main.tf",
+				Start:    posOf(file.Bytes, start),
+				End:      posOf(file.Bytes, start+len(substr)),
+			}
+		}
+		offset += idx + len(substr)
+	}
+}
+
+func Test_Fingerprint(t *testing.T) {
+	parse := func(src string) map[string]*hcl.File {
+		file, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		return map[string]*hcl.File{"main.tf": file}
+	}
+	issueAt := func(files map[string]*hcl.File, substr string, nth int) *Issue {
+		return &Issue{Rule: &testRule{}, Message: "test", Range: testRangeOf(t, files["main.tf"], substr, nth)}
+	}
+
+	original := parse(`
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "bar" {
+  instance_type = "t2.micro"
+}`)
+	shifted := parse(`
+# comment
+
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}`)
+	changed := parse(`
+resource "aws_instance" "foo" {
+  instance_type = "t3.micro"
+}`)
+
+	foo := Fingerprint(issueAt(original, `"t2.micro"`, 0), original)
+	bar := Fingerprint(issueAt(original, `"t2.micro"`, 1), original)
+
+	if foo == bar {
+		t.Errorf("issues in different blocks must have different fingerprints")
+	}
+	if got := Fingerprint(issueAt(shifted, `"t2.micro"`, 0), shifted); got != foo {
+		t.Errorf("line shifts must not change the fingerprint: want=%s, got=%s", foo, got)
+	}
+	if got := Fingerprint(issueAt(changed, `"t3.micro"`, 0), changed); got == foo {
+		t.Errorf("snippet changes must change the fingerprint")
+	}
+
+	withCaller := issueAt(original, `"t2.micro"`, 0)
+	withCaller.Callers = []hcl.Range{{Filename: "module/main.tf"}}
+	if got := Fingerprint(withCaller, original); got == foo {
+		t.Errorf("callers must change the fingerprint")
+	}
+}
+
+func Test_Address(t *testing.T) {
+	src := `
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+data "aws_ami" "bar" {
+  most_recent = true
+}
+
+module "baz" {
+  source = "./module"
+}
+
+locals {
+  foo = "bar"
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	files := map[string]*hcl.File{"main.tf": file}
+	module := addrs.RootModuleInstance.Child("app", addrs.StringKey("a"))
+
+	tests := []struct {
+		name   string
+		issue  *Issue
+		module addrs.ModuleInstance
+		want   string
+	}{
+		{
+			name:  "resource",
+			issue: &Issue{Range: testRangeOf(t, file, `"t2.micro"`, 0)},
+			want:  "aws_instance.foo",
+		},
+		{
+			name:  "data",
+			issue: &Issue{Range: testRangeOf(t, file, `most_recent`, 0)},
+			want:  "data.aws_ami.bar",
+		},
+		{
+			name:  "module call",
+			issue: &Issue{Range: testRangeOf(t, file, `"./module"`, 0)},
+			want:  "module.baz",
+		},
+		{
+			name:  "locals",
+			issue: &Issue{Range: testRangeOf(t, file, `foo = "bar"`, 0)},
+			want:  "",
+		},
+		{
+			name: "child module",
+			issue: &Issue{
+				Range:   hcl.Range{Filename: "root.tf"},
+				Callers: []hcl.Range{{Filename: "root.tf"}, testRangeOf(t, file, `"t2.micro"`, 0)},
+			},
+			module: module,
+			want:   `module.app["a"].aws_instance.foo`,
+		},
+		{
+			name:  "unknown file",
+			issue: &Issue{Range: hcl.Range{Filename: "unknown.tf"}},
+			want:  "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Address(test.issue, test.module, files)
+			if got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_blockAddress(t *testing.T) {
+	src := `
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+
+data "aws_ami" "bar" {
+  most_recent = true
+}
+
+module "baz" {
+  source = "./module"
+}
+
+locals {
+  foo = "bar"
+}
+
+terraform {
+  required_version = ">= 1.0"
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	tests := []struct {
+		substr string
+		want   string
+	}{
+		{substr: `"t2.micro"`, want: "aws_instance.foo"},
+		{substr: `most_recent`, want: "data.aws_ami.bar"},
+		{substr: `"./module"`, want: "module.baz"},
+		{substr: `foo = "bar"`, want: "locals"},
+		{substr: `required_version`, want: "terraform"},
+		{substr: "}\n", want: "aws_instance.foo"},
+	}
+
+	for _, test := range tests {
+		got := blockAddress(file, testRangeOf(t, file, test.substr, 0))
+		if got != test.want {
+			t.Errorf("%s: want=%s, got=%s", test.substr, test.want, got)
+		}
+	}
+
+	if got := blockAddress(file, hcl.Range{Start: hcl.Pos{Byte: len(file.Bytes) - 1}}); got != "" {
+		t.Errorf("outside of blocks: want=, got=%s", got)
+	}
+}
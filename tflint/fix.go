@@ -0,0 +1,105 @@
+package tflint
+
+import (
+	"bytes"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// Fix is a mechanical change that resolves an issue.
+// All edits of a fix are applied together, or not at all.
+type Fix struct {
+	Message string
+	Edits   []*TextEdit
+}
+
+// TextEdit replaces the text in the range with the new text.
+// An empty range inserts the text, and an empty text deletes the range.
+// Byte offsets of the range are used to apply the edit.
+type TextEdit struct {
+	Range   hcl.Range
+	NewText string
+}
+
+// ApplyFixes applies the fixes of the passed issues to the sources,
+// and returns the changed sources and the issues whose fixes are applied.
+//
+// Suppressed issues are not fixed. If the edits of a fix overlap with the edits
+// of a fix already accepted, or are out of the sources, the fix is skipped.
+// Skipped fixes are expected to be applied after inspecting the changed sources again.
+func ApplyFixes(issues Issues, sources map[string][]byte) (map[string][]byte, Issues) {
+	fixable := Issues{}
+	for _, issue := range issues.Unsuppressed() {
+		if issue.Fix != nil {
+			fixable = append(fixable, issue)
+		}
+	}
+
+	accepted := map[string][]*TextEdit{}
+	fixed := Issues{}
+	for _, issue := range fixable.Sort() {
+		if !canApplyFix(issue.Fix, accepted, sources) {
+			continue
+		}
+		for _, edit := range issue.Fix.Edits {
+			accepted[edit.Range.Filename] = append(accepted[edit.Range.Filename], edit)
+		}
+		fixed = append(fixed, issue)
+	}
+
+	ret := map[string][]byte{}
+	for filename, edits := range accepted {
+		// Apply from the end so that the offsets of the remaining edits are kept
+		sort.SliceStable(edits, func(i, j int) bool {
+			return edits[i].Range.Start.Byte > edits[j].Range.Start.Byte
+		})
+
+		src := bytes.Clone(sources[filename])
+		for _, edit := range edits {
+			src = append(src[:edit.Range.Start.Byte], append([]byte(edit.NewText), src[edit.Range.End.Byte:]...)...)
+		}
+		if !bytes.Equal(src, sources[filename]) {
+			ret[filename] = src
+		}
+	}
+
+	return ret, fixed
+}
+
+func canApplyFix(fix *Fix, accepted map[string][]*TextEdit, sources map[string][]byte) bool {
+	if len(fix.Edits) == 0 {
+		return false
+	}
+
+	for i, edit := range fix.Edits {
+		src, exists := sources[edit.Range.Filename]
+		if !exists {
+			return false
+		}
+		if edit.Range.Start.Byte < 0 || edit.Range.Start.Byte > edit.Range.End.Byte || edit.Range.End.Byte > len(src) {
+			return false
+		}
+
+		for _, other := range accepted[edit.Range.Filename] {
+			if edit.overlaps(other) {
+				return false
+			}
+		}
+		for _, other := range fix.Edits[:i] {
+			if edit.Range.Filename == other.Range.Filename && edit.overlaps(other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// overlaps returns whether the edits conflict. Insertions at the same position
+// also conflict because the order of the inserted texts is ambiguous.
+func (e *TextEdit) overlaps(other *TextEdit) bool {
+	if e.Range.Start.Byte == other.Range.Start.Byte {
+		return true
+	}
+	return e.Range.Start.Byte < other.Range.End.Byte && other.Range.Start.Byte < e.Range.End.Byte
+}
@@ -0,0 +1,127 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_ApplyFixes(t *testing.T) {
+	edit := func(filename string, start, end int, text string) *TextEdit {
+		return &TextEdit{
+			Range:   hcl.Range{Filename: filename, Start: hcl.Pos{Line: 1, Column: start + 1, Byte: start}, End: hcl.Pos{Line: 1, Column: end + 1, Byte: end}},
+			NewText: text,
+		}
+	}
+	issue := func(filename string, start int, edits ...*TextEdit) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range:   hcl.Range{Filename: filename, Start: hcl.Pos{Line: 1, Column: start + 1, Byte: start}},
+			Fix:     &Fix{Message: "fix", Edits: edits},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		issues    Issues
+		want      map[string][]byte
+		wantFixed int
+	}{
+		{
+			name: "replace, insert, and delete",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 3, "bar")),
+				issue("main.tf", 6, edit("main.tf", 6, 6, "2")),
+				issue("sub.tf", 0, edit("sub.tf", 3, 7, "")),
+			},
+			want: map[string][]byte{
+				"main.tf": []byte("bar = 21"),
+				"sub.tf":  []byte("baz"),
+			},
+			wantFixed: 3,
+		},
+		{
+			name: "multiple edits",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 3, "bar"), edit("main.tf", 6, 7, "2")),
+			},
+			want: map[string][]byte{
+				"main.tf": []byte("bar = 2"),
+			},
+			wantFixed: 1,
+		},
+		{
+			name: "overlapping edits",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 3, "bar")),
+				issue("main.tf", 2, edit("main.tf", 2, 7, "")),
+			},
+			want: map[string][]byte{
+				"main.tf": []byte("bar = 1"),
+			},
+			wantFixed: 1,
+		},
+		{
+			name: "insertions at the same position",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 0, "# a\n")),
+				issue("main.tf", 1, edit("main.tf", 0, 0, "# b\n")),
+			},
+			want: map[string][]byte{
+				"main.tf": []byte("# a\nfoo = 1"),
+			},
+			wantFixed: 1,
+		},
+		{
+			name: "suppressed",
+			issues: Issues{
+				func() *Issue {
+					i := issue("main.tf", 0, edit("main.tf", 0, 3, "bar"))
+					i.Suppression = &Suppression{Kind: SuppressionInSource}
+					return i
+				}(),
+			},
+			want:      map[string][]byte{},
+			wantFixed: 0,
+		},
+		{
+			name: "out of sources",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 100, "")),
+				issue("unknown.tf", 0, edit("unknown.tf", 0, 1, "")),
+			},
+			want:      map[string][]byte{},
+			wantFixed: 0,
+		},
+		{
+			name: "no changes",
+			issues: Issues{
+				issue("main.tf", 0, edit("main.tf", 0, 3, "foo")),
+			},
+			want:      map[string][]byte{},
+			wantFixed: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sources := map[string][]byte{
+				"main.tf": []byte("foo = 1"),
+				"sub.tf":  []byte("baz = 1"),
+			}
+
+			got, fixed := ApplyFixes(test.issues, sources)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+			if len(fixed) != test.wantFixed {
+				t.Errorf("expected %d fixed issues, but got %d", test.wantFixed, len(fixed))
+			}
+			if string(sources["main.tf"]) != "foo = 1" {
+				t.Errorf("sources must not be changed, but got %q", sources["main.tf"])
+			}
+		})
+	}
+}
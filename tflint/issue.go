@@ -6,6 +6,7 @@ import (
 
 	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/addrs"
 )
 
 // Issue represents a problem in configurations
@@ -14,6 +15,64 @@ type Issue struct {
 	Message string
 	Range   hcl.Range
 	Callers []hcl.Range
+
+	// Fix is a mechanical change that resolves the issue. Nil if not fixable.
+	Fix *Fix
+	// Metadata is machine-readable information about the issue, such as the locked provider version.
+	// Values must be serializable as JSON. Only core rules set this and Remediation,
+	// as the plugin SDK cannot send them.
+	Metadata map[string]interface{}
+	// Remediation is a short text describing how to resolve the issue.
+	Remediation string
+	// Suppression is set if the issue is suppressed by annotations, etc.
+	Suppression *Suppression
+	// Fingerprint is an identifier of the issue that is stable across unrelated changes.
+	// This is set by the caller with Fingerprint after inspection.
+	Fingerprint string
+	// Address is the address of the object enclosing the issue, like "module.app.aws_instance.web".
+	// This is set by the caller with Address after inspection. Empty if not applicable.
+	Address string
+	// Instances is the module instances that emitted the issue.
+	// The caller sets the emitting module instance on issues from child modules,
+	// and Dedupe collapses identical issues into one with all the instances.
+	// This is nil unless the issue is emitted by multiple module instances.
+	Instances []*IssueInstance
+}
+
+// IssueInstance is a module instance that emitted the issue
+type IssueInstance struct {
+	Module  addrs.ModuleInstance
+	Callers []hcl.Range
+}
+
+// SuppressionKind indicates how the issue is suppressed.
+// The values follow the suppression kinds in SARIF.
+type SuppressionKind string
+
+const (
+	// SuppressionInSource means that the issue is suppressed by annotations
+	SuppressionInSource SuppressionKind = "inSource"
+	// SuppressionExternal means that the issue is suppressed outside of source files
+	SuppressionExternal SuppressionKind = "external"
+)
+
+// Suppression represents why the issue is suppressed
+type Suppression struct {
+	Kind          SuppressionKind
+	Justification string
+	// Range is the location where the suppression is declared, such as annotations.
+	Range hcl.Range
+}
+
+// severityOverriddenRule is a rule whose severity is overridden by the rule config.
+type severityOverriddenRule struct {
+	Rule
+	severity Severity
+}
+
+// Severity returns the overridden severity
+func (r *severityOverriddenRule) Severity() Severity {
+	return r.severity
 }
 
 // Issues is an alias for the map of Issue
@@ -50,6 +109,70 @@ func SeverityToInt32(s Severity) (int32, error) {
 	}
 }
 
+// Unsuppressed returns issues that are not suppressed
+func (issues Issues) Unsuppressed() Issues {
+	ret := Issues{}
+	for _, issue := range issues {
+		if issue.Suppression == nil {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
+
+// Suppressed returns issues that are suppressed
+func (issues Issues) Suppressed() Issues {
+	ret := Issues{}
+	for _, issue := range issues {
+		if issue.Suppression != nil {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
+
+// Dedupe collapses identical issues emitted by multiple module instances into one.
+// Issues are identical if the rule, message, range, and suppression state are the same.
+// The first issue is kept, and the instances of the collapsed issues are appended to it.
+// Issues without instances, such as issues in the root module, are kept as is.
+func (issues Issues) Dedupe() Issues {
+	type issueKey struct {
+		rule       string
+		message    string
+		rng        string
+		suppressed bool
+	}
+
+	ret := Issues{}
+	seen := map[issueKey]*Issue{}
+	for _, issue := range issues {
+		if len(issue.Instances) == 0 {
+			ret = append(ret, issue)
+			continue
+		}
+
+		key := issueKey{
+			rule:       issue.Rule.Name(),
+			message:    issue.Message,
+			rng:        issue.Range.String(),
+			suppressed: issue.Suppression != nil,
+		}
+		if first, exists := seen[key]; exists {
+			first.Instances = append(first.Instances, issue.Instances...)
+			continue
+		}
+		seen[key] = issue
+		ret = append(ret, issue)
+	}
+
+	for _, issue := range ret {
+		if len(issue.Instances) < 2 {
+			issue.Instances = nil
+		}
+	}
+	return ret
+}
+
 // Sort returns the sorted receiver
 func (issues Issues) Sort() Issues {
 	sort.Slice(issues, func(i, j int) bool {
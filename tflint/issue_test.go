@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/addrs"
 )
 
 func Test_NewSeverity(t *testing.T) {
@@ -226,3 +227,97 @@ func Test_Sort(t *testing.T) {
 		t.Fatalf("Failed: diff=%s", cmp.Diff(got, expected))
 	}
 }
+
+func Test_Dedupe(t *testing.T) {
+	rng := hcl.Range{
+		Filename: "module/main.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1},
+		End:      hcl.Pos{Line: 1, Column: 2},
+	}
+	callerA := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 1}, End: hcl.Pos{Line: 3, Column: 2}}
+	callerB := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 8, Column: 1}, End: hcl.Pos{Line: 8, Column: 2}}
+	moduleA := addrs.RootModuleInstance.Child("app", addrs.StringKey("a"))
+	moduleB := addrs.RootModuleInstance.Child("app", addrs.StringKey("b"))
+	moduleC := addrs.RootModuleInstance.Child("other", addrs.NoKey)
+
+	issues := Issues{
+		{
+			Rule:      &testRule{},
+			Message:   "test",
+			Range:     rng,
+			Callers:   []hcl.Range{callerA, rng},
+			Instances: []*IssueInstance{{Module: moduleA, Callers: []hcl.Range{callerA, rng}}},
+		},
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range:   rng,
+		},
+		{
+			Rule:      &testRule{},
+			Message:   "test",
+			Range:     rng,
+			Callers:   []hcl.Range{callerA, rng},
+			Instances: []*IssueInstance{{Module: moduleB, Callers: []hcl.Range{callerA, rng}}},
+		},
+		{
+			Rule:      &testRule{},
+			Message:   "other message",
+			Range:     rng,
+			Callers:   []hcl.Range{callerB, rng},
+			Instances: []*IssueInstance{{Module: moduleC, Callers: []hcl.Range{callerB, rng}}},
+		},
+		{
+			Rule:      &testRule{},
+			Message:   "test",
+			Range:     rng,
+			Callers:   []hcl.Range{callerB, rng},
+			Instances: []*IssueInstance{{Module: moduleC, Callers: []hcl.Range{callerB, rng}}},
+		},
+		{
+			Rule:        &testRule{},
+			Message:     "test",
+			Range:       rng,
+			Callers:     []hcl.Range{callerB, rng},
+			Instances:   []*IssueInstance{{Module: moduleC, Callers: []hcl.Range{callerB, rng}}},
+			Suppression: &Suppression{Kind: SuppressionInSource},
+		},
+	}
+
+	expected := Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range:   rng,
+			Callers: []hcl.Range{callerA, rng},
+			Instances: []*IssueInstance{
+				{Module: moduleA, Callers: []hcl.Range{callerA, rng}},
+				{Module: moduleB, Callers: []hcl.Range{callerA, rng}},
+				{Module: moduleC, Callers: []hcl.Range{callerB, rng}},
+			},
+		},
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range:   rng,
+		},
+		{
+			Rule:    &testRule{},
+			Message: "other message",
+			Range:   rng,
+			Callers: []hcl.Range{callerB, rng},
+		},
+		{
+			Rule:        &testRule{},
+			Message:     "test",
+			Range:       rng,
+			Callers:     []hcl.Range{callerB, rng},
+			Suppression: &Suppression{Kind: SuppressionInSource},
+		},
+	}
+
+	got := issues.Dedupe()
+	if !cmp.Equal(got, expected) {
+		t.Fatalf("Failed: diff=%s", cmp.Diff(got, expected))
+	}
+}
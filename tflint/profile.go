@@ -0,0 +1,185 @@
+package tflint
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/terraform-linters/tflint/terraform/addrs"
+)
+
+const (
+	// ProfileCategoryCheck is the category of spans for checking rulesets against modules.
+	// A span covers a whole Check call of a ruleset, as plugins don't report when each rule starts and finishes.
+	ProfileCategoryCheck = "check"
+	// ProfileCategoryRPC is the category of spans for requests from plugins
+	ProfileCategoryRPC = "rpc"
+)
+
+// Profiler records the time spent in inspection.
+// All methods can be called on a nil profiler, and do nothing in that case.
+// This allows callers to record spans without checking whether profiling is enabled.
+type Profiler struct {
+	start time.Time
+	spans []*ProfileSpan
+	mu    sync.Mutex
+}
+
+// ProfileSpan is a recorded period of an operation.
+// The span is identified by the category and the name, like "rpc" and "EvaluateExpr".
+// The module is the address of the module instance that the operation is performed for,
+// or "root" for the root module.
+type ProfileSpan struct {
+	Category string
+	Name     string
+	Module   string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// NewProfiler returns a new profiler that starts recording now
+func NewProfiler() *Profiler {
+	return &Profiler{start: time.Now(), spans: []*ProfileSpan{}}
+}
+
+// Start starts recording a span and returns a function to end it
+func (p *Profiler) Start(category string, name string, module addrs.ModuleInstance) func() {
+	if p == nil {
+		return func() {}
+	}
+
+	moduleName := "root"
+	if !module.IsRoot() {
+		moduleName = module.String()
+	}
+	span := &ProfileSpan{Category: category, Name: name, Module: moduleName, Start: time.Now()}
+	return func() {
+		span.Duration = time.Since(span.Start)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.spans = append(p.spans, span)
+	}
+}
+
+// Spans returns the recorded spans in order of start
+func (p *Profiler) Spans() []*ProfileSpan {
+	if p == nil {
+		return []*ProfileSpan{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	spans := make([]*ProfileSpan, len(p.spans))
+	copy(spans, p.spans)
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	return spans
+}
+
+// ProfileSummary is the aggregated time of the recorded spans.
+// Rulesets and Modules are aggregated from spans of checking rulesets,
+// and RPCs from spans of requests from plugins.
+type ProfileSummary struct {
+	Rulesets []*ProfileEntry `json:"rulesets"`
+	Modules  []*ProfileEntry `json:"modules"`
+	RPCs     []*ProfileEntry `json:"rpcs"`
+}
+
+// ProfileEntry is the aggregated time of spans with the same name
+type ProfileEntry struct {
+	Name  string        `json:"name"`
+	Count int           `json:"count"`
+	Total time.Duration `json:"total_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// Summary returns the aggregated time. Entries are sorted by the total time in descending order.
+func (p *Profiler) Summary() *ProfileSummary {
+	rulesets := map[string]*ProfileEntry{}
+	modules := map[string]*ProfileEntry{}
+	rpcs := map[string]*ProfileEntry{}
+
+	for _, span := range p.Spans() {
+		switch span.Category {
+		case ProfileCategoryCheck:
+			addProfileEntry(rulesets, span.Name, span.Duration)
+			addProfileEntry(modules, span.Module, span.Duration)
+		case ProfileCategoryRPC:
+			addProfileEntry(rpcs, span.Name, span.Duration)
+		}
+	}
+
+	return &ProfileSummary{
+		Rulesets: sortProfileEntries(rulesets),
+		Modules:  sortProfileEntries(modules),
+		RPCs:     sortProfileEntries(rpcs),
+	}
+}
+
+func addProfileEntry(entries map[string]*ProfileEntry, name string, duration time.Duration) {
+	entry, exists := entries[name]
+	if !exists {
+		entry = &ProfileEntry{Name: name}
+		entries[name] = entry
+	}
+	entry.Count++
+	entry.Total += duration
+	if duration > entry.Max {
+		entry.Max = duration
+	}
+}
+
+func sortProfileEntries(entries map[string]*ProfileEntry) []*ProfileEntry {
+	ret := make([]*ProfileEntry, 0, len(entries))
+	for _, entry := range entries {
+		ret = append(ret, entry)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Total != ret[j].Total {
+			return ret[i].Total > ret[j].Total
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// traceEvent is an event in the Chrome trace event format.
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// WriteTrace writes the recorded spans in the Chrome trace event format.
+// The output can be loaded in chrome://tracing or Perfetto.
+func (p *Profiler) WriteTrace(w io.Writer) error {
+	events := []traceEvent{}
+	for _, span := range p.Spans() {
+		event := traceEvent{
+			Name:      span.Name,
+			Category:  span.Category,
+			Phase:     "X",
+			Timestamp: span.Start.Sub(p.start).Microseconds(),
+			Duration:  span.Duration.Microseconds(),
+			PID:       1,
+			TID:       1,
+		}
+		if span.Module != "" {
+			event.Args = map[string]string{"module": span.Module}
+		}
+		events = append(events, event)
+	}
+
+	return json.NewEncoder(w).Encode(map[string][]traceEvent{"traceEvents": events})
+}
@@ -0,0 +1,93 @@
+package tflint
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint/terraform/addrs"
+)
+
+func testProfiler() *Profiler {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	return &Profiler{
+		start: start,
+		spans: []*ProfileSpan{
+			{Category: ProfileCategoryRPC, Name: "EvaluateExpr", Module: "root", Start: at(2), Duration: 1 * time.Millisecond},
+			{Category: ProfileCategoryCheck, Name: "aws", Module: "root", Start: at(0), Duration: 10 * time.Millisecond},
+			{Category: ProfileCategoryCheck, Name: "aws", Module: "module.app", Start: at(10), Duration: 30 * time.Millisecond},
+			{Category: ProfileCategoryCheck, Name: "terraform", Module: "root", Start: at(40), Duration: 5 * time.Millisecond},
+			{Category: ProfileCategoryRPC, Name: "EvaluateExpr", Module: "module.app", Start: at(12), Duration: 3 * time.Millisecond},
+			{Category: ProfileCategoryRPC, Name: "GetModuleContent", Module: "root", Start: at(41), Duration: 2 * time.Millisecond},
+		},
+	}
+}
+
+func Test_Profiler_Summary(t *testing.T) {
+	got := testProfiler().Summary()
+
+	want := &ProfileSummary{
+		Rulesets: []*ProfileEntry{
+			{Name: "aws", Count: 2, Total: 40 * time.Millisecond, Max: 30 * time.Millisecond},
+			{Name: "terraform", Count: 1, Total: 5 * time.Millisecond, Max: 5 * time.Millisecond},
+		},
+		Modules: []*ProfileEntry{
+			{Name: "module.app", Count: 1, Total: 30 * time.Millisecond, Max: 30 * time.Millisecond},
+			{Name: "root", Count: 2, Total: 15 * time.Millisecond, Max: 10 * time.Millisecond},
+		},
+		RPCs: []*ProfileEntry{
+			{Name: "EvaluateExpr", Count: 2, Total: 4 * time.Millisecond, Max: 3 * time.Millisecond},
+			{Name: "GetModuleContent", Count: 1, Total: 2 * time.Millisecond, Max: 2 * time.Millisecond},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_Profiler_WriteTrace(t *testing.T) {
+	profiler := testProfiler()
+	profiler.spans = profiler.spans[:3]
+
+	out := &bytes.Buffer{}
+	if err := profiler.WriteTrace(out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"traceEvents":[` +
+		`{"name":"aws","cat":"check","ph":"X","ts":0,"dur":10000,"pid":1,"tid":1,"args":{"module":"root"}},` +
+		`{"name":"EvaluateExpr","cat":"rpc","ph":"X","ts":2000,"dur":1000,"pid":1,"tid":1,"args":{"module":"root"}},` +
+		`{"name":"aws","cat":"check","ph":"X","ts":10000,"dur":30000,"pid":1,"tid":1,"args":{"module":"module.app"}}` +
+		"]}\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_Profiler_Start(t *testing.T) {
+	profiler := NewProfiler()
+	profiler.Start(ProfileCategoryCheck, "aws", addrs.RootModuleInstance)()
+	profiler.Start(ProfileCategoryCheck, "aws", addrs.RootModuleInstance.Child("app", addrs.IntKey(0)))()
+
+	spans := profiler.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, but got %d", len(spans))
+	}
+	if spans[0].Module != "root" {
+		t.Errorf(`expected the module to be "root", but got %q`, spans[0].Module)
+	}
+	if spans[1].Module != "module.app[0]" {
+		t.Errorf(`expected the module to be "module.app[0]", but got %q`, spans[1].Module)
+	}
+
+	// A nil profiler does nothing
+	var disabled *Profiler
+	disabled.Start(ProfileCategoryCheck, "aws", addrs.RootModuleInstance)()
+	if len(disabled.Spans()) != 0 {
+		t.Error("expected no spans in a nil profiler")
+	}
+}
@@ -1,16 +1,23 @@
 package tflint
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/terraform/addrs"
+	"github.com/terraform-linters/tflint/terraform/didyoumean"
 	"github.com/terraform-linters/tflint/terraform/lang"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+	"golang.org/x/exp/slices"
 )
 
 // Runner checks templates according rules.
@@ -21,10 +28,37 @@ type Runner struct {
 	Issues   Issues
 	Ctx      *terraform.Evaluator
 
+	// SuppressedIssues is issues suppressed by annotations or ignore blocks in config files.
+	// These are not reported as problems, but are output with the justification
+	// in formats that support suppressions.
+	SuppressedIssues Issues
+
+	// ProviderLocks is the provider selections recorded in the dependency lock file.
+	// This is shared by the root module runner and its child module runners.
+	ProviderLocks *terraform.ProviderLocks
+	// Excludes is patterns of files to be excluded from inspection.
+	// Issues located in the excluded files are not emitted.
+	Excludes *terraform.Excludes
+	// ModuleInstance is the address of the module instance inspected by the runner.
+	// Unlike Ctx.ModulePath, it includes the instance keys of modules called with count/for_each.
+	ModuleInstance addrs.ModuleInstance
+	// Profiler records the time spent in inspecting the module. Nil if profiling is disabled.
+	// This is shared by the root module runner and its child module runners.
+	Profiler *Profiler
+
 	annotations map[string]Annotations
 	config      *Config
 	currentExpr hcl.Expression
 	modVars     map[string]*moduleVariable
+
+	// usedAnnotations is the set of annotations that suppressed any issue,
+	// keyed by the range of the annotation. This is shared by all runners.
+	usedAnnotations map[hcl.Range]bool
+	// usedIgnores is the set of ignore blocks in config files that suppressed any issue.
+	// This is shared by all runners as well as usedAnnotations.
+	usedIgnores map[*ConfigIgnore]bool
+	// allModuleFiles caches the files returned by moduleFiles. Nil until it is first called.
+	allModuleFiles map[string]*hcl.File
 }
 
 // Rule is interface for building the issue
@@ -60,12 +94,15 @@ func NewRunner(originalWorkingDir string, c *Config, ants map[string]Annotations
 	}
 
 	runner := &Runner{
-		TFConfig: cfg,
-		Issues:   Issues{},
-
-		Ctx:         ctx,
-		annotations: ants,
-		config:      c,
+		TFConfig:         cfg,
+		Issues:           Issues{},
+		SuppressedIssues: Issues{},
+
+		Ctx:             ctx,
+		annotations:     ants,
+		usedAnnotations: map[hcl.Range]bool{},
+		usedIgnores:     map[*ConfigIgnore]bool{},
+		config:          c,
 	}
 
 	return runner, nil
@@ -116,7 +153,13 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 			}
 		}
 
-		for _, body := range moduleCallBodies {
+		keys := moduleInstanceKeys(moduleCall, parent.Ctx)
+		if len(keys) != len(moduleCallBodies) {
+			// The expansion could not be reproduced. Fall back to the unkeyed instances.
+			keys = make([]addrs.InstanceKey, len(moduleCallBodies))
+		}
+
+		for i, body := range moduleCallBodies {
 			modVars := map[string]*moduleVariable{}
 			inputs := terraform.InputValues{}
 			for varName, attribute := range body.Attributes {
@@ -157,6 +200,12 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 				return runners, err
 			}
 			runner.modVars = modVars
+			runner.ModuleInstance = parent.ModuleInstance.Child(moduleCall.Name, keys[i])
+			runner.usedAnnotations = parent.usedAnnotations
+			runner.usedIgnores = parent.usedIgnores
+			runner.ProviderLocks = parent.ProviderLocks
+			runner.Excludes = parent.Excludes
+			runner.Profiler = parent.Profiler
 			runners = append(runners, runner)
 			moduleRunners, err := NewModuleRunners(runner)
 			if err != nil {
@@ -169,14 +218,70 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 	return runners, nil
 }
 
+// moduleInstanceKeys returns the instance keys of the module call in the same order
+// as the module blocks expanded by count/for_each. It returns nil if the keys cannot be determined.
+func moduleInstanceKeys(call *terraform.ModuleCall, ctx *terraform.Evaluator) []addrs.InstanceKey {
+	switch {
+	case call.Count != nil:
+		val, diags := ctx.EvaluateExpr(call.Count, cty.Number)
+		val, _ = val.Unmark()
+		if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+			return nil
+		}
+		var count int
+		if err := gocty.FromCtyValue(val, &count); err != nil {
+			return nil
+		}
+		keys := []addrs.InstanceKey{}
+		for idx := 0; idx < count; idx++ {
+			keys = append(keys, addrs.IntKey(idx))
+		}
+		return keys
+
+	case call.ForEach != nil:
+		val, diags := ctx.EvaluateExpr(call.ForEach, cty.DynamicPseudoType)
+		val, _ = val.Unmark()
+		if diags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.CanIterateElements() {
+			return nil
+		}
+		keys := []addrs.InstanceKey{}
+		for it := val.ElementIterator(); it.Next(); {
+			key, _ := it.Element()
+			key, _ = key.Unmark()
+			if !key.IsKnown() || key.IsNull() {
+				keys = append(keys, addrs.NoKey)
+				continue
+			}
+			instanceKey, err := addrs.ParseInstanceKey(key)
+			if err != nil {
+				instanceKey = addrs.NoKey
+			}
+			keys = append(keys, instanceKey)
+		}
+		return keys
+
+	default:
+		return []addrs.InstanceKey{addrs.NoKey}
+	}
+}
+
 // LookupIssues returns issues according to the received files
 func (r *Runner) LookupIssues(files ...string) Issues {
+	return lookupIssues(r.Issues, files...)
+}
+
+// LookupSuppressedIssues returns suppressed issues according to the received files
+func (r *Runner) LookupSuppressedIssues(files ...string) Issues {
+	return lookupIssues(r.SuppressedIssues, files...)
+}
+
+func lookupIssues(src Issues, files ...string) Issues {
 	if len(files) == 0 {
-		return r.Issues
+		return src
 	}
 
 	issues := Issues{}
-	for _, issue := range r.Issues {
+	for _, issue := range src {
 		for _, file := range files {
 			if filepath.Clean(file) == filepath.Clean(issue.Range.Filename) {
 				issues = append(issues, issue)
@@ -228,6 +333,275 @@ func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range) {
 	}
 }
 
+// CheckProviderLocks emits issues for provider requirements that the locked
+// provider versions don't satisfy. Requirements in child modules are also
+// checked, so call this only for the root module runner.
+// Providers that are not recorded in the lock file are ignored.
+func (r *Runner) CheckProviderLocks() {
+	if !r.config.coreRuleEnabled(providerLockMismatchRule) || r.ProviderLocks == nil {
+		return
+	}
+
+	var walk func(cfg *terraform.Config)
+	walk = func(cfg *terraform.Config) {
+		names := make([]string, 0, len(cfg.Module.ProviderRequirements))
+		for name := range cfg.Module.ProviderRequirements {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			req := cfg.Module.ProviderRequirements[name]
+			lock := r.ProviderLocks.Provider(req.Source)
+			if lock == nil || lock.Version == nil || req.Requirement == nil {
+				continue
+			}
+			if !req.Requirement.Check(lock.Version) {
+				r.emitIssue(&Issue{
+					Rule:    providerLockMismatchRule,
+					Message: fmt.Sprintf("The locked version %s of provider %q does not satisfy the version constraint %q", lock.VersionStr, req.Source, req.RequirementStr),
+					Range:   req.RequirementRange,
+					Metadata: map[string]interface{}{
+						"provider":           req.Source,
+						"locked_version":     lock.VersionStr,
+						"version_constraint": req.RequirementStr,
+					},
+					Remediation: "Run `terraform init -upgrade` to update the lock file, or change the version constraint",
+				})
+			}
+		}
+
+		childNames := make([]string, 0, len(cfg.Children))
+		for name := range cfg.Children {
+			childNames = append(childNames, name)
+		}
+		sort.Strings(childNames)
+		for _, name := range childNames {
+			walk(cfg.Children[name])
+		}
+	}
+	walk(r.TFConfig)
+}
+
+// CheckAnnotations emits issues for annotations that no longer suppress issues,
+// such as expired annotations and annotations without a required reason,
+// and for annotations with invalid options.
+// Expired ignore blocks in config files are also reported.
+// Annotations are shared by all runners, so call this only for the root module runner.
+func (r *Runner) CheckAnnotations() {
+	filenames := make([]string, 0, len(r.annotations))
+	for filename := range r.annotations {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	now := time.Now()
+	for _, filename := range filenames {
+		for _, annotation := range r.annotations[filename] {
+			if r.config.coreRuleEnabled(invalidAnnotationOptionRule) {
+				for _, message := range annotation.InvalidOptions {
+					r.emitIssue(&Issue{
+						Rule:    invalidAnnotationOptionRule,
+						Message: fmt.Sprintf("The annotation for %q has an invalid option: %s", annotation.Content, message),
+						Range:   annotation.Token.Range,
+					})
+				}
+			}
+			if annotation.IsExpired(now) && r.config.coreRuleEnabled(expiredAnnotationRule) {
+				r.emitIssue(&Issue{
+					Rule:    expiredAnnotationRule,
+					Message: fmt.Sprintf("The annotation for %q expired on %s", annotation.Content, annotation.Until.Format(annotationDateLayout)),
+					Range:   annotation.Token.Range,
+				})
+			}
+			if r.config.RequireAnnotationReason && annotation.Reason == "" && r.config.coreRuleEnabled(annotationReasonRule) {
+				r.emitIssue(&Issue{
+					Rule:    annotationReasonRule,
+					Message: fmt.Sprintf("The annotation for %q must have a reason", annotation.Content),
+					Range:   annotation.Token.Range,
+				})
+			}
+		}
+	}
+
+	for _, ignore := range r.config.Ignores {
+		if ignore.IsExpired(now) && r.config.coreRuleEnabled(expiredAnnotationRule) {
+			r.emitIssue(&Issue{
+				Rule:    expiredAnnotationRule,
+				Message: fmt.Sprintf("The ignore block for %q expired on %s", ignore.Rule, ignore.Until.Format(annotationDateLayout)),
+				Range:   ignore.DeclRange,
+			})
+		}
+	}
+}
+
+// CheckUnusedAnnotations emits issues for annotations that did not suppress any issues,
+// and for annotations that refer to rules unknown to any loaded ruleset.
+// Ignore blocks in config files are reported in the same way.
+// This is enabled by `report_unused_annotations`. Call this only for the root module runner
+// after all rules are checked. Rule names are verified only after Config.ValidateRules is called.
+func (r *Runner) CheckUnusedAnnotations() {
+	if !r.config.ReportUnusedAnnotations {
+		return
+	}
+
+	filenames := make([]string, 0, len(r.annotations))
+	for filename := range r.annotations {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	// Emit issues after checking all annotations, as emitting issues may mark annotations as used.
+	issues := Issues{}
+	for _, filename := range filenames {
+		for _, annotation := range r.annotations[filename] {
+			unknown := false
+			if r.config.knownRules != nil {
+				for _, rule := range annotation.RuleNames() {
+					if rule == "all" || slices.Contains(r.config.knownRules, rule) {
+						continue
+					}
+					unknown = true
+
+					if r.config.coreRuleEnabled(unknownAnnotationRuleRule) {
+						message := fmt.Sprintf("The annotation refers to an unknown rule %q", rule)
+						if suggestion := didyoumean.NameSuggestion(rule, r.config.knownRules); suggestion != "" {
+							message = fmt.Sprintf("%s. Did you mean %q?", message, suggestion)
+						}
+						issues = append(issues, &Issue{
+							Rule:    unknownAnnotationRuleRule,
+							Message: message,
+							Range:   annotation.Token.Range,
+						})
+					}
+				}
+			}
+
+			// Expired annotations, etc. are reported by CheckAnnotations
+			if unknown || r.usedAnnotations[annotation.Token.Range] || !r.isEffectiveAnnotation(annotation) {
+				continue
+			}
+			// Annotations for disabled rules are not reported, as they are needed
+			// when the rules are enabled by other options, such as --only or profiles.
+			enabled := true
+			for _, rule := range annotation.RuleNames() {
+				if !r.config.ruleEnabled(rule, r.absPath(filename)) {
+					enabled = false
+					break
+				}
+			}
+			if !enabled {
+				continue
+			}
+			if r.config.coreRuleEnabled(unusedAnnotationRule) {
+				issues = append(issues, &Issue{
+					Rule:    unusedAnnotationRule,
+					Message: fmt.Sprintf("The annotation for %q does not suppress any issues", annotation.Content),
+					Range:   annotation.Token.Range,
+					Fix:     r.removeAnnotationFix(annotation),
+				})
+			}
+		}
+	}
+
+	issues = append(issues, r.unusedIgnoreIssues()...)
+
+	for _, issue := range issues {
+		r.emitIssue(issue)
+	}
+}
+
+// unusedIgnoreIssues returns issues for ignore blocks that did not suppress any issues,
+// or that refer to unknown rules. Ignore blocks that only target files outside the inspected
+// modules are not reported, as config files can be shared by multiple directories.
+// Ignore blocks for disabled rules are not reported either.
+func (r *Runner) unusedIgnoreIssues() Issues {
+	issues := Issues{}
+
+	files := r.moduleFiles()
+	for _, ignore := range r.config.Ignores {
+		if r.config.knownRules != nil && ignore.Rule != "all" && !slices.Contains(r.config.knownRules, ignore.Rule) {
+			if r.config.coreRuleEnabled(unknownAnnotationRuleRule) {
+				message := fmt.Sprintf("The ignore block refers to an unknown rule %q", ignore.Rule)
+				if suggestion := didyoumean.NameSuggestion(ignore.Rule, r.config.knownRules); suggestion != "" {
+					message = fmt.Sprintf("%s. Did you mean %q?", message, suggestion)
+				}
+				issues = append(issues, &Issue{
+					Rule:    unknownAnnotationRuleRule,
+					Message: message,
+					Range:   ignore.DeclRange,
+				})
+			}
+			continue
+		}
+
+		// Expired ignore blocks are reported by CheckAnnotations
+		if r.usedIgnores[ignore] || ignore.IsExpired(time.Now()) {
+			continue
+		}
+		// Ignore blocks for rules disabled in all matching files are not reported, like annotations.
+		inspected := false
+		for name := range files {
+			path := r.absPath(name)
+			if ignore.MatchFile(path) && r.config.ruleEnabled(ignore.Rule, path) {
+				inspected = true
+				break
+			}
+		}
+		if !inspected {
+			continue
+		}
+		if r.config.coreRuleEnabled(unusedAnnotationRule) {
+			issues = append(issues, &Issue{
+				Rule:    unusedAnnotationRule,
+				Message: fmt.Sprintf("The ignore block for %q does not suppress any issues", ignore.Rule),
+				Range:   ignore.DeclRange,
+			})
+		}
+	}
+
+	return issues
+}
+
+// removeAnnotationFix returns a fix removing the annotation comment.
+// If the comment is the only content of the line, the whole line is removed.
+// Annotations in JSON syntax are not fixable because they are object properties.
+func (r *Runner) removeAnnotationFix(annotation Annotation) *Fix {
+	rng := annotation.Token.Range
+	src, exists := r.Sources()[rng.Filename]
+	if !exists || strings.HasSuffix(rng.Filename, ".json") || rng.End.Byte > len(src) {
+		return nil
+	}
+
+	// Line comments include the trailing newline
+	start, end := rng.Start.Byte, rng.End.Byte
+	for end > start && (src[end-1] == '\n' || src[end-1] == '\r') {
+		end--
+	}
+
+	lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+	lineEnd := len(src)
+	if idx := bytes.IndexByte(src[end:], '\n'); idx >= 0 {
+		lineEnd = end + idx + 1
+	}
+
+	if len(bytes.TrimSpace(src[lineStart:start])) == 0 && len(bytes.TrimSpace(src[end:lineEnd])) == 0 {
+		start, end = lineStart, lineEnd
+	} else {
+		// Remove the spaces before the trailing comment
+		for start > lineStart && (src[start-1] == ' ' || src[start-1] == '\t') {
+			start--
+		}
+	}
+
+	return &Fix{
+		Message: "Remove the annotation",
+		Edits: []*TextEdit{
+			{Range: hcl.Range{Filename: rng.Filename, Start: posOf(src, start), End: posOf(src, end)}},
+		},
+	}
+}
+
 // WithExpressionContext sets the context of the passed expression currently being processed.
 func (r *Runner) WithExpressionContext(expr hcl.Expression, proc func() error) error {
 	r.currentExpr = expr
@@ -236,9 +610,24 @@ func (r *Runner) WithExpressionContext(expr hcl.Expression, proc func() error) e
 	return err
 }
 
-// RuleConfig returns the corresponding rule configuration
+// RuleConfig returns the corresponding rule configuration.
+// If all files in the module match an override, the rule block in the override is returned.
 func (r *Runner) RuleConfig(ruleName string) *RuleConfig {
-	return r.config.Rules[ruleName]
+	paths := []string{}
+	for name := range r.Files() {
+		paths = append(paths, r.absPath(name))
+	}
+	config, _ := r.config.ruleConfigFor(ruleName, paths...)
+	return config
+}
+
+// absPath returns the absolute path of the passed file.
+// Relative paths are resolved from the original working directory.
+func (r *Runner) absPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(r.Ctx.Meta.OriginalWorkingDir, path)
 }
 
 // ConfigSources returns the sources of TFLint config files
@@ -247,17 +636,129 @@ func (r *Runner) ConfigSources() map[string][]byte {
 }
 
 func (r *Runner) emitIssue(issue *Issue) {
+	ruleConfig, overridden := r.config.ruleConfigFor(issue.Rule.Name(), r.absPath(issue.Range.Filename))
+	if overridden && !ruleConfig.Enabled {
+		log.Printf("[INFO] %s (%s) is ignored because the rule is disabled by overrides", issue.Range.String(), issue.Rule.Name())
+		return
+	}
+	issue.Rule = ruleConfig.applySeverity(issue.Rule)
+
+	if r.isExcluded(issue) {
+		log.Printf("[INFO] %s (%s) is ignored because the file is excluded", issue.Range.String(), issue.Rule.Name())
+		return
+	}
 	if annotations, ok := r.annotations[issue.Range.Filename]; ok {
 		for _, annotation := range annotations {
+			if !r.isEffectiveAnnotation(annotation) {
+				continue
+			}
 			if annotation.IsAffected(issue) {
 				log.Printf("[INFO] %s (%s) is ignored by %s", issue.Range.String(), issue.Rule.Name(), annotation.String())
+				issue.Suppression = &Suppression{
+					Kind:          SuppressionInSource,
+					Justification: annotation.Reason,
+					Range:         annotation.Token.Range,
+				}
+				r.SuppressedIssues = append(r.SuppressedIssues, issue)
+				r.usedAnnotations[annotation.Token.Range] = true
 				return
 			}
 		}
 	}
+	if ignore := r.affectingIgnore(issue); ignore != nil {
+		log.Printf("[INFO] %s (%s) is ignored by %s", issue.Range.String(), issue.Rule.Name(), ignore.String())
+		issue.Suppression = &Suppression{
+			Kind:          SuppressionExternal,
+			Justification: ignore.Reason,
+			Range:         ignore.DeclRange,
+		}
+		r.SuppressedIssues = append(r.SuppressedIssues, issue)
+		r.usedIgnores[ignore] = true
+		return
+	}
 	r.Issues = append(r.Issues, issue)
 }
 
+// affectingIgnore returns the first effective ignore block in config files that affects the issue.
+// It returns nil if no ignore blocks affect the issue.
+func (r *Runner) affectingIgnore(issue *Issue) *ConfigIgnore {
+	if len(r.config.Ignores) == 0 {
+		return nil
+	}
+
+	path := r.absPath(issue.Range.Filename)
+	// The address is computed only when needed, as it requires looking up the block containing the issue
+	var address *string
+	addressFunc := func() string {
+		if address == nil {
+			addr := Address(issue, r.ModuleInstance, r.moduleFiles())
+			address = &addr
+		}
+		return *address
+	}
+
+	now := time.Now()
+	for _, ignore := range r.config.Ignores {
+		if ignore.IsExpired(now) {
+			continue
+		}
+		if ignore.IsAffected(issue, path, addressFunc) {
+			return ignore
+		}
+	}
+	return nil
+}
+
+// moduleFiles returns the files of all modules in the configuration, including the root module.
+// Unlike Files, these include files of child modules, as issues can refer to them.
+// The files are collected only once per runner.
+func (r *Runner) moduleFiles() map[string]*hcl.File {
+	if r.allModuleFiles != nil {
+		return r.allModuleFiles
+	}
+	files := map[string]*hcl.File{}
+
+	var walk func(cfg *terraform.Config)
+	walk = func(cfg *terraform.Config) {
+		for name, file := range cfg.Module.Files {
+			files[name] = file
+		}
+		for _, child := range cfg.Children {
+			walk(child)
+		}
+	}
+	walk(r.TFConfig.Root)
+
+	r.allModuleFiles = files
+	return files
+}
+
+// isEffectiveAnnotation returns whether the annotation can suppress issues.
+// Expired annotations and annotations without a required reason are not effective.
+func (r *Runner) isEffectiveAnnotation(annotation Annotation) bool {
+	if annotation.IsExpired(time.Now()) {
+		return false
+	}
+	if r.config.RequireAnnotationReason && annotation.Reason == "" {
+		return false
+	}
+	return true
+}
+
+// isExcluded returns whether the issue is located in an excluded file.
+// An issue from a child module is also excluded if any of the callers is excluded.
+func (r *Runner) isExcluded(issue *Issue) bool {
+	if r.Excludes.Match(issue.Range.Filename, false) {
+		return true
+	}
+	for _, caller := range issue.Callers {
+		if r.Excludes.Match(caller.Filename, false) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Runner) listModuleVars(expr hcl.Expression) []*moduleVariable {
 	ret := []*moduleVariable{}
 	for _, ref := range listVarRefs(expr) {
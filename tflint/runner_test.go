@@ -3,10 +3,13 @@ package tflint
 import (
 	"errors"
 	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -167,6 +170,21 @@ func Test_NewModuleRunners_withCountForEach(t *testing.T) {
 		if diff := cmp.Diff(moduleNames, expected, cmpopts.SortSlices(less)); diff != "" {
 			t.Fatal(diff)
 		}
+
+		moduleInstances := make([]string, 5)
+		for idx, r := range runners {
+			moduleInstances[idx] = r.ModuleInstance.String()
+		}
+		expected = []string{
+			"module.count_is_one[0]",
+			"module.count_is_two[0]",
+			"module.count_is_two[1]",
+			"module.for_each_is_not_empty[0]",
+			"module.for_each_is_not_empty[1]",
+		}
+		if diff := cmp.Diff(moduleInstances, expected, cmpopts.SortSlices(less)); diff != "" {
+			t.Fatal(diff)
+		}
 	})
 }
 
@@ -407,7 +425,10 @@ func Test_EmitIssue(t *testing.T) {
 		Message     string
 		Location    hcl.Range
 		Annotations map[string]Annotations
-		Expected    Issues
+		Excludes    []string
+		// RequireReason sets require_annotation_reason
+		RequireReason bool
+		Expected      Issues
 	}{
 		{
 			Name:    "basic",
@@ -453,10 +474,124 @@ func Test_EmitIssue(t *testing.T) {
 			},
 			Expected: Issues{},
 		},
+		{
+			Name:    "expired annotation",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Annotations: map[string]Annotations{
+				"test.tf": {
+					{
+						Content: "test_rule",
+						Until:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local),
+						Token: hclsyntax.Token{
+							Type: hclsyntax.TokenComment,
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1},
+							},
+						},
+					},
+				},
+			},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+				},
+			},
+		},
+		{
+			Name:    "reason required",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Annotations: map[string]Annotations{
+				"test.tf": {
+					{
+						Content: "test_rule",
+						Token: hclsyntax.Token{
+							Type: hclsyntax.TokenComment,
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1},
+							},
+						},
+					},
+				},
+			},
+			RequireReason: true,
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+				},
+			},
+		},
+		{
+			Name:    "reason given",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Annotations: map[string]Annotations{
+				"test.tf": {
+					{
+						Content: "test_rule",
+						Reason:  "false positive",
+						Token: hclsyntax.Token{
+							Type: hclsyntax.TokenComment,
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1},
+							},
+						},
+					},
+				},
+			},
+			RequireReason: true,
+			Expected:      Issues{},
+		},
+		{
+			Name:    "excluded",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.generated.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Annotations: map[string]Annotations{},
+			Excludes:    []string{"*.generated.tf"},
+			Expected:    Issues{},
+		},
 	}
 
 	for _, tc := range cases {
 		runner := testRunnerWithAnnotations(t, map[string]string{}, tc.Annotations)
+		runner.Excludes = terraform.NewExcludes()
+		for _, pattern := range tc.Excludes {
+			if err := runner.Excludes.Add(".", pattern); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		runner.config.RequireAnnotationReason = tc.RequireReason
 
 		runner.EmitIssue(tc.Rule, tc.Message, tc.Location)
 
@@ -466,6 +601,393 @@ func Test_EmitIssue(t *testing.T) {
 	}
 }
 
+func Test_EmitIssue_suppressed(t *testing.T) {
+	annotations := map[string]Annotations{
+		"test.tf": {
+			{
+				Content: "test_rule",
+				Reason:  "false positive",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+				},
+			},
+		},
+	}
+	runner := testRunnerWithAnnotations(t, map[string]string{}, annotations)
+
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}})
+
+	expected := Issues{
+		{
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+			Suppression: &Suppression{
+				Kind:          SuppressionInSource,
+				Justification: "false positive",
+				Range:         hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, runner.SuppressedIssues); diff != "" {
+		t.Fatal(diff)
+	}
+	if len(runner.Issues) != 0 {
+		t.Fatalf("suppressed issues must not be emitted, but got %d issues", len(runner.Issues))
+	}
+}
+
+func Test_EmitIssue_severity(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+	runner.config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true, Severity: "notice"}
+
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}})
+
+	if len(runner.Issues) != 1 {
+		t.Fatalf("expected 1 issue, but got %d issues", len(runner.Issues))
+	}
+	issue := runner.Issues[0]
+	if issue.Rule.Severity() != sdk.NOTICE {
+		t.Errorf("expected severity is notice, but got %s", issue.Rule.Severity())
+	}
+	if issue.Rule.Name() != "test_rule" || issue.Rule.Link() != (&testRule{}).Link() {
+		t.Errorf("other attributes of the rule must not be changed")
+	}
+}
+
+func Test_EmitIssue_overrides(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+	runner.config.Overrides = []*ConfigOverride{
+		{
+			Files: []string{"examples/**"},
+			Rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: false},
+			},
+			baseDir: runner.Ctx.Meta.OriginalWorkingDir,
+		},
+		{
+			Files: []string{"legacy/**"},
+			Rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: true, Severity: "warning"},
+			},
+			baseDir: runner.Ctx.Meta.OriginalWorkingDir,
+		},
+	}
+
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: filepath.Join("examples", "main.tf"), Start: hcl.Pos{Line: 1}})
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: filepath.Join("legacy", "main.tf"), Start: hcl.Pos{Line: 1}})
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}})
+
+	if len(runner.Issues) != 2 {
+		t.Fatalf("expected 2 issues, but got %d issues", len(runner.Issues))
+	}
+	if runner.Issues[0].Range.Filename != filepath.Join("legacy", "main.tf") || runner.Issues[0].Rule.Severity() != sdk.WARNING {
+		t.Errorf("the severity must be overridden in legacy/main.tf")
+	}
+	if runner.Issues[1].Range.Filename != "main.tf" || runner.Issues[1].Rule.Severity() != sdk.ERROR {
+		t.Errorf("the severity must not be overridden in main.tf")
+	}
+}
+
+func Test_EmitIssue_ignores(t *testing.T) {
+	src := `
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+}`
+	// The range of instance_type
+	location := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 3, Byte: 34}}
+
+	tests := []struct {
+		name   string
+		ignore *ConfigIgnore
+		want   bool
+	}{
+		{
+			name:   "rule",
+			ignore: &ConfigIgnore{Rule: "test_rule"},
+			want:   true,
+		},
+		{
+			name:   "all",
+			ignore: &ConfigIgnore{Rule: "all"},
+			want:   true,
+		},
+		{
+			name:   "other rule",
+			ignore: &ConfigIgnore{Rule: "other_rule"},
+			want:   false,
+		},
+		{
+			name:   "files",
+			ignore: &ConfigIgnore{Rule: "test_rule", Files: []string{"*.tf"}},
+			want:   true,
+		},
+		{
+			name:   "other files",
+			ignore: &ConfigIgnore{Rule: "test_rule", Files: []string{"gen/**"}},
+			want:   false,
+		},
+		{
+			name:   "message",
+			ignore: &ConfigIgnore{Rule: "test_rule", MessageRegex: regexp.MustCompile("^This is")},
+			want:   true,
+		},
+		{
+			name:   "other message",
+			ignore: &ConfigIgnore{Rule: "test_rule", MessageRegex: regexp.MustCompile("^That is")},
+			want:   false,
+		},
+		{
+			name:   "address",
+			ignore: &ConfigIgnore{Rule: "test_rule", Address: "aws_instance.*", addressPattern: addressPattern("aws_instance.*")},
+			want:   true,
+		},
+		{
+			name:   "other address",
+			ignore: &ConfigIgnore{Rule: "test_rule", Address: "module.legacy.*", addressPattern: addressPattern("module.legacy.*")},
+			want:   false,
+		},
+		{
+			name:   "expired",
+			ignore: &ConfigIgnore{Rule: "test_rule", Until: time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)},
+			want:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{"main.tf": src}, map[string]Annotations{})
+			test.ignore.Reason = "generated"
+			test.ignore.DeclRange = hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 1}}
+			test.ignore.baseDir = runner.Ctx.Meta.OriginalWorkingDir
+			runner.config.Ignores = []*ConfigIgnore{test.ignore}
+
+			runner.EmitIssue(&testRule{}, "This is test message", location)
+
+			if !test.want {
+				if len(runner.Issues) != 1 {
+					t.Fatalf("expected 1 issue, but got %d issues", len(runner.Issues))
+				}
+				return
+			}
+			expected := Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range:   location,
+					Suppression: &Suppression{
+						Kind:          SuppressionExternal,
+						Justification: "generated",
+						Range:         hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 1}},
+					},
+				},
+			}
+			if diff := cmp.Diff(expected, runner.SuppressedIssues); diff != "" {
+				t.Fatal(diff)
+			}
+			if len(runner.Issues) != 0 {
+				t.Fatalf("suppressed issues must not be emitted, but got %d issues", len(runner.Issues))
+			}
+		})
+	}
+}
+
+func Test_RuleConfig_overrides(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{"main.tf": "", "legacy.tf": ""}, map[string]Annotations{})
+	runner.config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true}
+
+	override := &ConfigOverride{
+		Files: []string{"legacy.tf"},
+		Rules: map[string]*RuleConfig{
+			"test_rule": {Name: "test_rule", Enabled: false},
+		},
+		baseDir: runner.Ctx.Meta.OriginalWorkingDir,
+	}
+	runner.config.Overrides = []*ConfigOverride{override}
+
+	if got := runner.RuleConfig("test_rule"); !got.Enabled {
+		t.Errorf("the override must not be applied if any file in the module doesn't match")
+	}
+
+	override.Files = []string{"*.tf"}
+	if got := runner.RuleConfig("test_rule"); got.Enabled {
+		t.Errorf("the override must be applied if all files in the module match")
+	}
+}
+
+func Test_isExcluded(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  bool
+	}{
+		{
+			name:  "not excluded",
+			issue: &Issue{Range: hcl.Range{Filename: "main.tf"}},
+			want:  false,
+		},
+		{
+			name:  "excluded",
+			issue: &Issue{Range: hcl.Range{Filename: filepath.Join("examples", "main.tf")}},
+			want:  true,
+		},
+		{
+			name: "excluded caller",
+			issue: &Issue{
+				Range: hcl.Range{Filename: "main.tf"},
+				Callers: []hcl.Range{
+					{Filename: "main.tf"},
+					{Filename: filepath.Join("examples", "module", "main.tf")},
+				},
+			},
+			want: true,
+		},
+	}
+
+	runner := TestRunner(t, map[string]string{})
+	runner.Excludes = terraform.NewExcludes()
+	if err := runner.Excludes.Add(".", "examples/"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := runner.isExcluded(test.issue)
+			if got != test.want {
+				t.Errorf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_CheckProviderLocks(t *testing.T) {
+	locks := &terraform.ProviderLocks{
+		Providers: map[string]*terraform.ProviderLock{
+			"registry.terraform.io/hashicorp/aws": {
+				Addr:       "registry.terraform.io/hashicorp/aws",
+				Version:    version.Must(version.NewVersion("4.67.0")),
+				VersionStr: "4.67.0",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		src    string
+		config *Config
+		locks  *terraform.ProviderLocks
+		want   Issues
+	}{
+		{
+			name: "satisfied",
+			src: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+  }
+}`,
+			config: EmptyConfig(),
+			locks:  locks,
+			want:   Issues{},
+		},
+		{
+			name: "not satisfied",
+			src: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 3.0"
+    }
+  }
+}`,
+			config: EmptyConfig(),
+			locks:  locks,
+			want: Issues{
+				{
+					Rule:    providerLockMismatchRule,
+					Message: `The locked version 4.67.0 of provider "registry.terraform.io/hashicorp/aws" does not satisfy the version constraint "~> 3.0"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+					Metadata: map[string]interface{}{
+						"provider":           "registry.terraform.io/hashicorp/aws",
+						"locked_version":     "4.67.0",
+						"version_constraint": "~> 3.0",
+					},
+					Remediation: "Run `terraform init -upgrade` to update the lock file, or change the version constraint",
+				},
+			},
+		},
+		{
+			name: "not locked",
+			src: `
+terraform {
+  required_providers {
+    google = "~> 3.0"
+  }
+}`,
+			config: EmptyConfig(),
+			locks:  locks,
+			want:   Issues{},
+		},
+		{
+			name: "no lock file",
+			src: `
+terraform {
+  required_providers {
+    aws = "~> 3.0"
+  }
+}`,
+			config: EmptyConfig(),
+			locks:  nil,
+			want:   Issues{},
+		},
+		{
+			name: "disabled",
+			src: `
+terraform {
+  required_providers {
+    aws = "~> 3.0"
+  }
+}`,
+			config: &Config{
+				Rules: map[string]*RuleConfig{
+					"tflint_provider_lock_mismatch": {Name: "tflint_provider_lock_mismatch", Enabled: false},
+				},
+			},
+			locks: locks,
+			want:  Issues{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := TestRunnerWithConfig(t, map[string]string{"main.tf": test.src}, test.config)
+			runner.ProviderLocks = test.locks
+
+			runner.CheckProviderLocks()
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+				cmp.AllowUnexported(coreRule{}),
+			}
+			if diff := cmp.Diff(test.want, runner.Issues, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func Test_listVarRefs(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -523,3 +1045,409 @@ func Test_listVarRefs(t *testing.T) {
 		}
 	}
 }
+
+func Test_CheckAnnotations(t *testing.T) {
+	annotation := func(reason string, until time.Time) Annotation {
+		return Annotation{
+			Content: "test_rule",
+			Reason:  reason,
+			Until:   until,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+			},
+		}
+	}
+	expired := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	future := time.Now().AddDate(1, 0, 0)
+
+	tests := []struct {
+		name          string
+		annotation    Annotation
+		requireReason bool
+		want          Issues
+	}{
+		{
+			name:       "effective",
+			annotation: annotation("", future),
+			want:       Issues{},
+		},
+		{
+			name:       "expired",
+			annotation: annotation("legacy", expired),
+			want: Issues{
+				{
+					Rule:    expiredAnnotationRule,
+					Message: `The annotation for "test_rule" expired on 2020-01-01`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+		{
+			name:          "reason required",
+			annotation:    annotation("", time.Time{}),
+			requireReason: true,
+			want: Issues{
+				{
+					Rule:    annotationReasonRule,
+					Message: `The annotation for "test_rule" must have a reason`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+		{
+			name:          "reason given",
+			annotation:    annotation("legacy", time.Time{}),
+			requireReason: true,
+			want:          Issues{},
+		},
+		{
+			name: "invalid options",
+			annotation: func() Annotation {
+				a := annotation("legacy", time.Time{})
+				a.InvalidOptions = []string{"`reson` is not a valid option. Valid options are `reason` and `until`"}
+				return a
+			}(),
+			want: Issues{
+				{
+					Rule:    invalidAnnotationOptionRule,
+					Message: "The annotation for \"test_rule\" has an invalid option: `reson` is not a valid option. Valid options are `reason` and `until`",
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{"main.tf": {test.annotation}})
+			runner.config.RequireAnnotationReason = test.requireReason
+
+			runner.CheckAnnotations()
+
+			if diff := cmp.Diff(test.want, runner.Issues, cmp.AllowUnexported(coreRule{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_CheckAnnotations_ignores(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+	runner.config.Ignores = []*ConfigIgnore{
+		{
+			Rule:      "test_rule",
+			Until:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local),
+			DeclRange: hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 1}},
+		},
+		{
+			Rule:      "test_rule",
+			Until:     time.Now().AddDate(1, 0, 0),
+			DeclRange: hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 5}},
+		},
+	}
+
+	runner.CheckAnnotations()
+
+	want := Issues{
+		{
+			Rule:    expiredAnnotationRule,
+			Message: `The ignore block for "test_rule" expired on 2020-01-01`,
+			Range:   hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 1}},
+		},
+	}
+	if diff := cmp.Diff(want, runner.Issues, cmp.AllowUnexported(coreRule{})); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_CheckUnusedAnnotations(t *testing.T) {
+	annotation := func(content string, line int) Annotation {
+		return Annotation{
+			Content: content,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: line}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		annotation Annotation
+		issue      *Issue
+		knownRules []string
+		config     *Config
+		want       Issues
+	}{
+		{
+			name:       "used",
+			annotation: annotation("test_rule", 1),
+			issue:      &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2}}},
+			knownRules: []string{"test_rule"},
+			want:       Issues{},
+		},
+		{
+			name:       "unused",
+			annotation: annotation("test_rule", 1),
+			issue:      &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}}},
+			knownRules: []string{"test_rule"},
+			want: Issues{
+				{
+					Rule:  &testRule{},
+					Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}},
+				},
+				{
+					Rule:    unusedAnnotationRule,
+					Message: `The annotation for "test_rule" does not suppress any issues`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+		{
+			name:       "unknown rule with suggestion",
+			annotation: annotation("test_rul", 1),
+			knownRules: []string{"test_rule"},
+			want: Issues{
+				{
+					Rule:    unknownAnnotationRuleRule,
+					Message: `The annotation refers to an unknown rule "test_rul". Did you mean "test_rule"?`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+		{
+			name:       "unknown rule without suggestion",
+			annotation: annotation("aws_instance_invalid_type", 1),
+			knownRules: []string{"test_rule"},
+			want: Issues{
+				{
+					Rule:    unknownAnnotationRuleRule,
+					Message: `The annotation refers to an unknown rule "aws_instance_invalid_type"`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+		{
+			name:       "all",
+			annotation: annotation("all", 1),
+			issue:      &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}}},
+			knownRules: []string{"test_rule"},
+			want:       Issues{},
+		},
+		{
+			name:       "disabled rule",
+			annotation: annotation("test_rule", 1),
+			knownRules: []string{"test_rule"},
+			config: &Config{
+				Rules: map[string]*RuleConfig{
+					"test_rule": {Name: "test_rule", Enabled: false},
+				},
+			},
+			want: Issues{},
+		},
+		{
+			name:       "rule excluded by --only",
+			annotation: annotation("test_rule, other_rule", 1),
+			knownRules: []string{"other_rule", "test_rule"},
+			config:     &Config{Only: []string{"other_rule", "tflint_unused_annotation"}},
+			want:       Issues{},
+		},
+		{
+			name:       "disabled by default",
+			annotation: annotation("test_rule", 1),
+			knownRules: []string{"test_rule"},
+			config: &Config{
+				DisabledByDefault: true,
+				Rules: map[string]*RuleConfig{
+					"tflint_unused_annotation": {Name: "tflint_unused_annotation", Enabled: true},
+				},
+			},
+			want: Issues{},
+		},
+		{
+			name:       "rules are not validated",
+			annotation: annotation("unknown_rule", 1),
+			want: Issues{
+				{
+					Rule:    unusedAnnotationRule,
+					Message: `The annotation for "unknown_rule" does not suppress any issues`,
+					Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{"main.tf": {test.annotation}})
+			if test.config != nil {
+				runner.config.Rules = test.config.Rules
+				runner.config.Only = test.config.Only
+				runner.config.DisabledByDefault = test.config.DisabledByDefault
+			}
+			runner.config.ReportUnusedAnnotations = true
+			runner.config.knownRules = test.knownRules
+
+			if test.issue != nil {
+				runner.emitIssue(test.issue)
+			}
+			runner.CheckUnusedAnnotations()
+
+			if diff := cmp.Diff(test.want, runner.Issues, cmp.AllowUnexported(coreRule{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_CheckUnusedAnnotations_ignores(t *testing.T) {
+	declRange := hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 1}}
+
+	tests := []struct {
+		name       string
+		ignore     *ConfigIgnore
+		issue      *Issue
+		knownRules []string
+		rules      map[string]*RuleConfig
+		want       Issues
+	}{
+		{
+			name:       "used",
+			ignore:     &ConfigIgnore{Rule: "test_rule"},
+			issue:      &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}}},
+			knownRules: []string{"test_rule"},
+			want:       Issues{},
+		},
+		{
+			name:       "unused",
+			ignore:     &ConfigIgnore{Rule: "test_rule", Files: []string{"*.tf"}},
+			knownRules: []string{"test_rule"},
+			want: Issues{
+				{
+					Rule:    unusedAnnotationRule,
+					Message: `The ignore block for "test_rule" does not suppress any issues`,
+					Range:   declRange,
+				},
+			},
+		},
+		{
+			name:       "files outside the module",
+			ignore:     &ConfigIgnore{Rule: "test_rule", Files: []string{"gen/**"}},
+			knownRules: []string{"test_rule"},
+			want:       Issues{},
+		},
+		{
+			name:       "disabled rule",
+			ignore:     &ConfigIgnore{Rule: "test_rule"},
+			knownRules: []string{"test_rule"},
+			rules: map[string]*RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: false},
+			},
+			want: Issues{},
+		},
+		{
+			name:       "expired",
+			ignore:     &ConfigIgnore{Rule: "test_rule", Until: time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)},
+			knownRules: []string{"test_rule"},
+			want:       Issues{},
+		},
+		{
+			name:       "unknown rule",
+			ignore:     &ConfigIgnore{Rule: "test_rul"},
+			knownRules: []string{"test_rule"},
+			want: Issues{
+				{
+					Rule:    unknownAnnotationRuleRule,
+					Message: `The ignore block refers to an unknown rule "test_rul". Did you mean "test_rule"?`,
+					Range:   declRange,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{"main.tf": ""}, map[string]Annotations{})
+			if test.rules != nil {
+				runner.config.Rules = test.rules
+			}
+			runner.config.ReportUnusedAnnotations = true
+			runner.config.knownRules = test.knownRules
+			test.ignore.DeclRange = declRange
+			test.ignore.baseDir = runner.Ctx.Meta.OriginalWorkingDir
+			runner.config.Ignores = []*ConfigIgnore{test.ignore}
+
+			if test.issue != nil {
+				runner.emitIssue(test.issue)
+			}
+			runner.CheckUnusedAnnotations()
+
+			if diff := cmp.Diff(test.want, runner.Issues, cmp.AllowUnexported(coreRule{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_CheckUnusedAnnotations_fix(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "line comment",
+			src: `resource "null_resource" "foo" {
+  # tflint-ignore: test_rule
+  foo = 1
+}`,
+			want: `resource "null_resource" "foo" {
+  foo = 1
+}`,
+		},
+		{
+			name: "trailing comment",
+			src: `resource "null_resource" "foo" {
+  foo = 1 // tflint-ignore: test_rule
+}`,
+			want: `resource "null_resource" "foo" {
+  foo = 1
+}`,
+		},
+		{
+			name: "block comment",
+			src: `resource "null_resource" "foo" {
+  foo = /* tflint-ignore: test_rule */ 1
+}`,
+			want: `resource "null_resource" "foo" {
+  foo = 1
+}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(test.src), "main.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			annotations, diags := NewAnnotations("main.tf", file)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			runner := testRunnerWithAnnotations(t, map[string]string{"main.tf": test.src}, map[string]Annotations{"main.tf": annotations})
+			runner.config.ReportUnusedAnnotations = true
+			runner.CheckUnusedAnnotations()
+
+			changes, fixed := ApplyFixes(runner.Issues, runner.Sources())
+			if len(fixed) != 1 {
+				t.Fatalf("expected 1 fixed issue, but got %d", len(fixed))
+			}
+			if diff := cmp.Diff(test.want, string(changes["main.tf"])); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}